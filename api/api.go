@@ -22,6 +22,10 @@ const (
 	ActionPlaySpell
 	ActionDiscoverPick
 	ActionReorderCards
+	ActionPickHero
+	ActionUseHeroPower
+	ActionEndTurn
+	ActionFollow
 )
 
 func (a Action) String() string {
@@ -46,6 +50,14 @@ func (a Action) String() string {
 		return "discover_pick"
 	case ActionReorderCards:
 		return "reorder_cards"
+	case ActionPickHero:
+		return "pick_hero"
+	case ActionUseHeroPower:
+		return "use_hero_power"
+	case ActionEndTurn:
+		return "end_turn"
+	case ActionFollow:
+		return "follow"
 	default:
 		return "unknown"
 	}
@@ -66,8 +78,9 @@ type SellMinion struct {
 }
 
 type PlaceMinion struct {
-	HandIndex     int `json:"hand_index"`
-	BoardPosition int `json:"board_position"`
+	HandIndex     int  `json:"hand_index"`
+	BoardPosition int  `json:"board_position"`
+	TargetIndex   *int `json:"target_index,omitzero"` // board index for a targeted Battlecry, nil for none
 }
 
 type RemoveMinion struct {
@@ -80,21 +93,132 @@ type ReorderCards struct {
 }
 
 type PlaySpell struct {
-	HandIndex int `json:"hand_index"`
+	HandIndex   int  `json:"hand_index"`
+	TargetIndex *int `json:"target_index,omitzero"` // board index for spells aimed at a friendly minion, nil for none
 }
 
 type DiscoverPick struct {
 	Index int `json:"index"`
 }
 
-// HTTP types for lobby creation.
+type PickHero struct {
+	Index int `json:"index"` // index in GameState.HeroOffers
+}
+
+type UseHeroPower struct {
+	TargetIndex *int `json:"target_index,omitzero"` // board index for powers aimed at a friendly minion, nil for none
+}
+
+// Follow switches the player a spectator connection follows. It is the only
+// action spectators may send.
+type Follow struct {
+	Player game.PlayerID `json:"player"`
+}
+
+// HTTP types for lobby creation and joining. The returned token
+// authenticates the player's WebSocket connection.
+//
+// Player IDs are chosen by the client and not authenticated: anyone may join
+// a lobby as any ID not yet taken in it. The token only proves its holder is
+// the one who joined with that ID.
 
 type CreateLobbyReq struct {
-	MaxPlayers int `json:"max_players"`
+	MaxPlayers int           `json:"max_players"`
+	Tribes     game.Tribes   `json:"tribes,omitzero"` // tribes in the card pool, 0 for random
+	Player     game.PlayerID `json:"player"`          // creator, joins the lobby
 }
 
 type CreateLobbyResp struct {
 	LobbyID string `json:"lobby_id"`
+	Token   string `json:"token"`
+}
+
+type JoinLobbyReq struct {
+	Player game.PlayerID `json:"player"`
+}
+
+type JoinLobbyResp struct {
+	Token string `json:"token"`
+}
+
+// HTTP types for spectating. A lobby player invites spectators, the returned
+// token authenticates the spectator's WebSocket connection. Spectators follow
+// the inviting player only, until that player is eliminated.
+
+type InviteSpectatorReq struct {
+	Token string `json:"token"` // the inviting player's session token
+}
+
+type InviteSpectatorResp struct {
+	Token string `json:"token"`
+}
+
+// HTTP types for matchmaking. Players poll their ticket until a lobby is
+// found, then connect with the returned token. As with joining, player IDs
+// are not authenticated.
+
+type EnqueueReq struct {
+	Player     game.PlayerID `json:"player"`
+	MaxPlayers int           `json:"max_players"`
+}
+
+type EnqueueResp struct {
+	Ticket string `json:"ticket"`
+}
+
+type MatchState uint8
+
+const (
+	MatchQueued   MatchState = iota + 1
+	MatchTimedOut            // queued too long, the player may accept a smaller lobby
+	MatchFound
+)
+
+func (s MatchState) String() string {
+	switch s {
+	case MatchQueued:
+		return "queued"
+	case MatchTimedOut:
+		return "timed_out"
+	case MatchFound:
+		return "found"
+	default:
+		return "unknown"
+	}
+}
+
+type Match struct {
+	State   MatchState `json:"state"`
+	Queued  int        `json:"queued,omitzero"`   // players waiting for the same lobby size
+	LobbyID string     `json:"lobby_id,omitzero"` // found only
+	Token   string     `json:"token,omitzero"`    // found only
+}
+
+// HTTP types for lobby discovery.
+
+type ListLobbiesResp struct {
+	Lobbies []LobbySummary `json:"lobbies"` // waiting lobbies, oldest first
+}
+
+type LobbySummary struct {
+	ID         string `json:"id"`
+	Players    int    `json:"players"`
+	MaxPlayers int    `json:"max_players"`
+	Age        int    `json:"age"` // seconds since the lobby was created
+}
+
+type Lobby struct {
+	ID      string        `json:"id"`
+	State   string        `json:"state"`
+	Turn    int           `json:"turn"`
+	Phase   game.Phase    `json:"phase"`
+	Players []LobbyPlayer `json:"players"`
+}
+
+type LobbyPlayer struct {
+	ID    game.PlayerID `json:"id"`
+	HP    int           `json:"hp"`
+	Armor int           `json:"armor,omitzero"`
 }
 
 // ServerMessage represents message which server must send to a client.
@@ -105,10 +229,12 @@ type ServerMessage struct {
 	OpponentUpdate *OpponentUpdate `json:"opponent_update,omitempty"`
 }
 
-// OpponentUpdate is a lightweight notification about an opponent's tier change.
+// OpponentUpdate is a lightweight notification about an opponent's tier or
+// ready change.
 type OpponentUpdate struct {
 	Player   game.PlayerID `json:"player"`
-	ShopTier game.Tier     `json:"shop_tier"`
+	ShopTier game.Tier     `json:"shop_tier,omitzero"` // 0 if the tier didn't change
+	Ready    bool          `json:"ready,omitzero"`
 }
 
 type GameState struct {
@@ -124,39 +250,96 @@ type GameState struct {
 	Discovers     []Card         `json:"discovers,omitempty"`
 	CombatResults []CombatResult `json:"combat_results,omitempty"`
 	Opponent      game.PlayerID  `json:"opponent"`                 // combat phase only
+	IsGhost       bool           `json:"is_ghost,omitzero"`        // opponent is the ghost of an eliminated player
 	CombatBoard   []Card         `json:"combat_board,omitempty"`   // combat phase only
 	OpponentBoard []Card         `json:"opponent_board,omitempty"` // combat phase only
 	GameResult    *GameResult    `json:"game_result,omitempty"`
+	HeroOffers    []Hero         `json:"hero_offers,omitempty"` // hero select phase only
+	Tribes        game.Tribes    `json:"tribes"`                // tribes in the lobby's card pool
 }
 
 type Player struct {
 	ID          game.PlayerID `json:"id"`
 	HP          int           `json:"hp"`
+	Armor       int           `json:"armor,omitzero"`
 	Gold        int           `json:"gold"`
 	MaxGold     int           `json:"max_gold"`
 	ShopTier    game.Tier     `json:"shop_tier"`
 	UpgradeCost int           `json:"upgrade_cost"`
 	RefreshCost int           `json:"refresh_cost"`
+	Hero        *Hero         `json:"hero,omitempty"`
+	Ready       bool          `json:"ready,omitzero"` // ended turn, recruit phase only
 }
 
 type Opponent struct {
 	ID            game.PlayerID  `json:"id"`
 	HP            int            `json:"hp"`
+	Armor         int            `json:"armor,omitzero"`
 	ShopTier      game.Tier      `json:"shop_tier"`
 	CombatResults []CombatResult `json:"combat_results,omitempty"`
 	TopTribe      game.Tribe     `json:"top_tribe,omitzero"`
 	TopTribeCount int            `json:"top_tribe_count,omitzero"`
+	Hero          *Hero          `json:"hero,omitempty"`
+	Ready         bool           `json:"ready,omitzero"` // ended turn, recruit phase only
+}
+
+type Hero struct {
+	ID    string    `json:"id"`
+	Name  string    `json:"name"`
+	HP    int       `json:"hp"`             // starting health
+	Armor int       `json:"armor,omitzero"` // starting armor
+	Power HeroPower `json:"power"`
+}
+
+type HeroPower struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Passive     bool   `json:"passive,omitzero"`
+	Cost        int    `json:"cost,omitzero"`
+	NeedsTarget bool   `json:"needs_target,omitzero"`
+	Used        bool   `json:"used,omitzero"` // used this turn, own hero only
+}
+
+// NewHero returns hero info, or nil if no hero is picked.
+func NewHero(h game.HeroTemplate) *Hero {
+	if h == nil {
+		return nil
+	}
+	power := h.Power()
+	return &Hero{
+		ID:    h.ID(),
+		Name:  h.Name(),
+		HP:    h.HP(),
+		Armor: h.Armor(),
+		Power: HeroPower{
+			Name:        power.Name,
+			Description: power.Description,
+			Passive:     power.Passive,
+			Cost:        power.Cost,
+			NeedsTarget: power.NeedsTarget(),
+		},
+	}
+}
+
+func NewHeroes(heroes []game.HeroTemplate) []Hero {
+	res := make([]Hero, len(heroes))
+	for i, h := range heroes {
+		res[i] = *NewHero(h)
+	}
+	return res
 }
 
 type Card struct {
-	Template string        `json:"template"`
-	Tribes   game.Tribes   `json:"tribes"`
-	Attack   int           `json:"attack"`
-	Health   int           `json:"health"`
-	IsGolden bool          `json:"is_golden,omitzero"`
-	Cost     int           `json:"cost,omitzero"`
-	Keywords game.Keywords `json:"keywords,omitzero"`
-	CombatID game.CombatID `json:"combat_id,omitzero"` // set only in combat context
+	Template   string        `json:"template"`
+	Tribes     game.Tribes   `json:"tribes"`
+	Attack     int           `json:"attack"`               // effective, including auras
+	Health     int           `json:"health"`               // effective, including auras
+	AuraAttack bool          `json:"aura_attack,omitzero"` // attack boosted by an aura
+	AuraHealth bool          `json:"aura_health,omitzero"` // health boosted by an aura
+	IsGolden   bool          `json:"is_golden,omitzero"`
+	Cost       int           `json:"cost,omitzero"`
+	Keywords   game.Keywords `json:"keywords,omitzero"`
+	CombatID   game.CombatID `json:"combat_id,omitzero"` // set only in combat context
 }
 
 // CombatEvent is the JSON envelope: type discriminator + raw payload.
@@ -179,16 +362,18 @@ func NewCombatEvents(events []game.CombatEvent) ([]CombatEvent, error) {
 }
 
 type CombatResult struct {
-	Opponent game.PlayerID `json:"opponent"`
-	Winner   game.PlayerID `json:"winner"`
-	Damage   int           `json:"damage"`
+	Opponent  game.PlayerID `json:"opponent"`
+	Winner    game.PlayerID `json:"winner"`
+	Damage    int           `json:"damage"`              // after the damage cap
+	RawDamage int           `json:"raw_damage,omitzero"` // before the damage cap
 }
 
 func NewCombatResult(cr game.CombatResult) CombatResult {
 	return CombatResult{
-		Opponent: cr.Opponent,
-		Winner:   cr.Winner,
-		Damage:   cr.Damage,
+		Opponent:  cr.Opponent,
+		Winner:    cr.Winner,
+		Damage:    cr.Damage,
+		RawDamage: cr.RawDamage,
 	}
 }
 
@@ -213,15 +398,20 @@ type Error struct {
 }
 
 func NewPlayer(p *game.Player) Player {
-	return Player{
+	res := Player{
 		ID:          p.ID(),
 		HP:          p.HP(),
+		Armor:       p.Armor(),
 		Gold:        p.Gold(),
 		MaxGold:     p.MaxGold(),
 		ShopTier:    p.Shop().Tier(),
 		UpgradeCost: p.Shop().UpgradeCost(),
 		RefreshCost: p.Shop().RefreshCost(),
 	}
+	if res.Hero = NewHero(p.Hero()); res.Hero != nil {
+		res.Hero.Power.Used = p.HeroPowerUsed()
+	}
+	return res
 }
 
 func NewOpponents(
@@ -229,6 +419,7 @@ func NewOpponents(
 	exclude game.PlayerID,
 	combatResults map[game.PlayerID][]CombatResult,
 	tribes map[game.PlayerID]game.TopTribe,
+	ready map[game.PlayerID]bool,
 ) []Opponent {
 	res := make([]Opponent, 0, len(players)-1)
 	for _, p := range players {
@@ -239,10 +430,13 @@ func NewOpponents(
 		res = append(res, Opponent{
 			ID:            p.ID(),
 			HP:            p.HP(),
+			Armor:         p.Armor(),
 			ShopTier:      p.Shop().Tier(),
 			CombatResults: combatResults[p.ID()],
 			TopTribe:      snap.Tribe,
 			TopTribeCount: snap.Count,
+			Hero:          NewHero(p.Hero()),
+			Ready:         ready[p.ID()],
 		})
 	}
 	return res
@@ -250,15 +444,7 @@ func NewOpponents(
 
 func NewCard(c game.Card) Card {
 	if m, ok := c.(*game.Minion); ok {
-		return Card{
-			Template: m.TemplateID(),
-			Attack:   m.Attack(),
-			Health:   m.Health(),
-			Cost:     m.Cost(),
-			IsGolden: m.IsGolden(),
-			Tribes:   m.Tribes(),
-			Keywords: m.Keywords(),
-		}
+		return NewCardFromMinion(m)
 	}
 	return Card{
 		Template: c.Template().ID(),
@@ -268,25 +454,29 @@ func NewCard(c game.Card) Card {
 
 func NewCardFromMinion(m *game.Minion) Card {
 	return Card{
-		Template: m.TemplateID(),
-		Attack:   m.Attack(),
-		Health:   m.Health(),
-		Cost:     m.Cost(),
-		IsGolden: m.IsGolden(),
-		Tribes:   m.Tribes(),
-		Keywords: m.Keywords(),
+		Template:   m.TemplateID(),
+		Attack:     m.Attack(),
+		Health:     m.Health(),
+		AuraAttack: m.AuraAttack() > 0,
+		AuraHealth: m.AuraHealth() > 0,
+		Cost:       m.Cost(),
+		IsGolden:   m.IsGolden(),
+		Tribes:     m.Tribes(),
+		Keywords:   m.Keywords(),
 	}
 }
 
 func NewCombatCard(m *game.Minion) Card {
 	return Card{
-		Template: m.TemplateID(),
-		Attack:   m.Attack(),
-		Health:   m.Health(),
-		IsGolden: m.IsGolden(),
-		Tribes:   m.Tribes(),
-		Keywords: m.Keywords(),
-		CombatID: m.CombatID(),
+		Template:   m.TemplateID(),
+		Attack:     m.Attack(),
+		Health:     m.Health(),
+		AuraAttack: m.AuraAttack() > 0,
+		AuraHealth: m.AuraHealth() > 0,
+		IsGolden:   m.IsGolden(),
+		Tribes:     m.Tribes(),
+		Keywords:   m.Keywords(),
+		CombatID:   m.CombatID(),
 	}
 }
 
@@ -351,3 +541,84 @@ func NewGameResult(r *game.GameResult) *GameResult {
 		EndedAt:    r.EndedAt,
 	}
 }
+
+// CombatSnapshot is a serialized combat pairing: both boards before the fight,
+// as used by the combat simulator. Card stats and keywords are base values,
+// auras are rebuilt from the boards.
+type CombatSnapshot struct {
+	PlayerBoard   []Card    `json:"player_board"`
+	OpponentBoard []Card    `json:"opponent_board"`
+	PlayerTier    game.Tier `json:"player_tier,omitzero"`
+	OpponentTier  game.Tier `json:"opponent_tier,omitzero"`
+}
+
+func NewCombatSnapshot(playerBoard, opponentBoard game.Board, playerTier, opponentTier game.Tier) CombatSnapshot {
+	return CombatSnapshot{
+		PlayerBoard:   newSnapshotCards(playerBoard),
+		OpponentBoard: newSnapshotCards(opponentBoard),
+		PlayerTier:    playerTier,
+		OpponentTier:  opponentTier,
+	}
+}
+
+// newSnapshotCards returns the board's minions without their aura bonuses,
+// so auras don't outlive their source in a simulated fight.
+func newSnapshotCards(b game.Board) []Card {
+	cards := make([]Card, 0, b.Len())
+	for _, m := range b.Minions() {
+		c := NewCardFromMinion(m)
+		c.Attack -= m.AuraAttack()
+		c.Health -= m.AuraHealth()
+		c.AuraAttack = false
+		c.AuraHealth = false
+		c.Keywords = m.BaseKeywords()
+		cards = append(cards, c)
+	}
+	return cards
+}
+
+// SimSides converts the snapshot to simulator input.
+func (s CombatSnapshot) SimSides(cards game.CardCatalog) (player, opponent game.SimSide, err error) {
+	pb, err := NewBoard(s.PlayerBoard, cards)
+	if err != nil {
+		return player, opponent, fmt.Errorf("player board: %w", err)
+	}
+	ob, err := NewBoard(s.OpponentBoard, cards)
+	if err != nil {
+		return player, opponent, fmt.Errorf("opponent board: %w", err)
+	}
+	player = game.SimSide{Player: 1, Board: pb, Tier: s.PlayerTier}
+	opponent = game.SimSide{Player: 2, Board: ob, Tier: s.OpponentTier}
+	return player, opponent, nil
+}
+
+// NewBoard rebuilds a board from cards. Card stats and keywords are the
+// minions' base values; auras are recomputed as minions are placed.
+func NewBoard(cards []Card, catalog game.CardCatalog) (game.Board, error) {
+	b := game.NewBoard(len(cards))
+	for i, c := range cards {
+		t := catalog.ByTemplateID(c.Template)
+		if t == nil {
+			return game.Board{}, fmt.Errorf("card %d: unknown template %q", i, c.Template)
+		}
+		if t.Kind() != game.CardKindMinion {
+			return game.Board{}, fmt.Errorf("card %d: %w", i, game.ErrNotAMinion)
+		}
+
+		m := game.NewMinion(t)
+		if c.IsGolden {
+			m = game.NewGoldenMinion(t)
+		}
+		for kw := range m.Keywords().Iter() {
+			if !c.Keywords.Has(kw) {
+				m.RemoveKeyword(kw)
+			}
+		}
+		for kw := range c.Keywords.Iter() {
+			m.AddKeyword(kw)
+		}
+		m.Buff(c.Attack-m.Attack(), c.Health-m.Health())
+		b.PlaceMinion(m, i)
+	}
+	return b, nil
+}
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/ysomad/gigabg/api"
+	"github.com/ysomad/gigabg/game"
 )
 
 // Client makes HTTP calls to the game server.
@@ -35,32 +36,136 @@ func New(addr, proxyURL string) *Client {
 	}
 }
 
-// CreateLobby creates a new lobby and returns the lobby ID.
-func (c *Client) CreateLobby(ctx context.Context, maxPlayers int) (string, error) {
+// CreateLobby creates a new lobby with the player in it and returns the lobby
+// ID and the player's session token.
+func (c *Client) CreateLobby(ctx context.Context, player game.PlayerID, maxPlayers int) (lobbyID, token string, err error) {
 	var resp api.CreateLobbyResp
 	if err := c.sendRequest(
 		ctx,
 		http.MethodPost,
 		fmt.Sprintf("http://%s/lobbies", c.addr),
-		api.CreateLobbyReq{MaxPlayers: maxPlayers},
+		api.CreateLobbyReq{MaxPlayers: maxPlayers, Player: player},
+		&resp,
+	); err != nil {
+		return "", "", err
+	}
+	return resp.LobbyID, resp.Token, nil
+}
+
+// JoinLobby adds the player to the lobby and returns the player's session token.
+func (c *Client) JoinLobby(ctx context.Context, lobbyID string, player game.PlayerID) (string, error) {
+	var resp api.JoinLobbyResp
+	if err := c.sendRequest(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("http://%s/lobbies/%s/players", c.addr, url.PathEscape(lobbyID)),
+		api.JoinLobbyReq{Player: player},
+		&resp,
+	); err != nil {
+		return "", err
+	}
+	return resp.Token, nil
+}
+
+// Lobbies returns the lobbies waiting for players, oldest first.
+func (c *Client) Lobbies(ctx context.Context) ([]api.LobbySummary, error) {
+	var resp api.ListLobbiesResp
+	if err := c.sendRequest(ctx, http.MethodGet, fmt.Sprintf("http://%s/lobbies", c.addr), nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Lobbies, nil
+}
+
+// Lobby returns the lobby's state and players.
+func (c *Client) Lobby(ctx context.Context, lobbyID string) (api.Lobby, error) {
+	var resp api.Lobby
+	if err := c.sendRequest(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("http://%s/lobbies/%s", c.addr, url.PathEscape(lobbyID)),
+		nil,
+		&resp,
+	); err != nil {
+		return api.Lobby{}, err
+	}
+	return resp, nil
+}
+
+// InviteSpectator returns a token letting its holder spectate the lobby with
+// NewSpectatorClient. The token is the inviting player's session token.
+func (c *Client) InviteSpectator(ctx context.Context, lobbyID, token string) (string, error) {
+	var resp api.InviteSpectatorResp
+	if err := c.sendRequest(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("http://%s/lobbies/%s/spectators", c.addr, url.PathEscape(lobbyID)),
+		api.InviteSpectatorReq{Token: token},
 		&resp,
 	); err != nil {
 		return "", err
 	}
-	return resp.LobbyID, nil
+	return resp.Token, nil
 }
 
+// Enqueue puts the player in the matchmaking queue for the lobby size and
+// returns the ticket to poll with PollMatch.
+func (c *Client) Enqueue(ctx context.Context, player game.PlayerID, maxPlayers int) (string, error) {
+	var resp api.EnqueueResp
+	if err := c.sendRequest(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("http://%s/matchmaking", c.addr),
+		api.EnqueueReq{Player: player, MaxPlayers: maxPlayers},
+		&resp,
+	); err != nil {
+		return "", err
+	}
+	return resp.Ticket, nil
+}
+
+// PollMatch waits a few seconds for the ticket's match and returns its state.
+// Call it again until the state is api.MatchFound.
+func (c *Client) PollMatch(ctx context.Context, ticket string) (api.Match, error) {
+	var resp api.Match
+	if err := c.sendRequest(ctx, http.MethodGet, c.ticketURL(ticket, ""), nil, &resp); err != nil {
+		return api.Match{}, err
+	}
+	return resp, nil
+}
+
+// AcceptSmallerLobby lets a timed out ticket start in a smaller lobby.
+func (c *Client) AcceptSmallerLobby(ctx context.Context, ticket string) error {
+	return c.sendRequest(ctx, http.MethodPost, c.ticketURL(ticket, "/smaller"), nil, nil)
+}
+
+// CancelMatch leaves the matchmaking queue.
+func (c *Client) CancelMatch(ctx context.Context, ticket string) error {
+	return c.sendRequest(ctx, http.MethodDelete, c.ticketURL(ticket, ""), nil, nil)
+}
+
+func (c *Client) ticketURL(ticket, suffix string) string {
+	return fmt.Sprintf("http://%s/matchmaking/%s%s", c.addr, url.PathEscape(ticket), suffix)
+}
+
+// sendRequest sends req as the JSON body and decodes the response into resp.
+// A nil req sends no body, a nil resp expects none.
 func (c *Client) sendRequest(ctx context.Context, method, url string, req, resp any) error {
-	body, err := json.Marshal(req)
-	if err != nil {
-		return fmt.Errorf("marshal: %w", err)
+	var body io.Reader
+	if req != nil {
+		data, err := json.Marshal(req)
+		if err != nil {
+			return fmt.Errorf("marshal: %w", err)
+		}
+		body = bytes.NewReader(data)
 	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	httpReq, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return fmt.Errorf("request: %w", err)
 	}
-	httpReq.Header.Set("Content-Type", "application/json")
+	if req != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
 
 	httpResp, err := c.client.Do(httpReq)
 	if err != nil {
@@ -73,7 +178,7 @@ func (c *Client) sendRequest(ctx context.Context, method, url string, req, resp
 	}
 	defer httpResp.Body.Close()
 
-	if httpResp.StatusCode != http.StatusOK {
+	if httpResp.StatusCode != http.StatusOK && httpResp.StatusCode != http.StatusNoContent {
 		respBody, err := io.ReadAll(httpResp.Body)
 		if err != nil {
 			return fmt.Errorf("%s %s %d: %w", method, url, httpResp.StatusCode, err)
@@ -82,6 +187,9 @@ func (c *Client) sendRequest(ctx context.Context, method, url string, req, resp
 		return fmt.Errorf("%s %s %d: %s", method, url, httpResp.StatusCode, strings.TrimSpace(string(respBody)))
 	}
 
+	if resp == nil {
+		return nil
+	}
 	if err := json.UnmarshalRead(httpResp.Body, resp); err != nil {
 		return fmt.Errorf("decode: %w", err)
 	}
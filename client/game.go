@@ -32,16 +32,25 @@ type GameClient struct {
 }
 
 // NewGameClient dials the game server WebSocket and returns a GameClient.
-// addr is host:port (e.g. "localhost:8080").
+// addr is host:port (e.g. "localhost:8080"), token is the session token
+// returned when the player joined or created the lobby.
 // If proxyURL is non-empty, the WebSocket connection is routed through the given HTTP proxy.
-func NewGameClient(
-	ctx context.Context,
-	addr string,
-	player game.PlayerID,
-	lobbyID, proxyURL string,
-) (*GameClient, error) {
-	wsURL := fmt.Sprintf("ws://%s/ws?player=%d&lobby=%s", addr, player, lobbyID)
+func NewGameClient(ctx context.Context, addr, token, proxyURL string) (*GameClient, error) {
+	return dial(ctx, fmt.Sprintf("ws://%s/ws?token=%s", addr, url.QueryEscape(token)), proxyURL)
+}
+
+// NewSpectatorClient connects to the lobby as a spectator following the
+// player. The token is a spectator grant from Client.InviteSpectator or a
+// player session of the lobby. Spectators receive the followed player's view
+// of the game behind a server-side delay, and can only switch players with
+// Follow. Until the token's player is eliminated, only that player can be
+// followed.
+func NewSpectatorClient(ctx context.Context, addr, lobbyID, token string, player game.PlayerID, proxyURL string) (*GameClient, error) {
+	return dial(ctx, fmt.Sprintf("ws://%s/ws?spectate=%s&player=%d&token=%s",
+		addr, url.QueryEscape(lobbyID), player, url.QueryEscape(token)), proxyURL)
+}
 
+func dial(ctx context.Context, wsURL, proxyURL string) (*GameClient, error) {
 	var opts *websocket.DialOptions
 	if proxyURL != "" {
 		u, _ := url.Parse(proxyURL)
@@ -101,7 +110,10 @@ func (c *GameClient) handleMessage(msg *api.ServerMessage) {
 		if c.state != nil {
 			for i := range c.state.Opponents {
 				if c.state.Opponents[i].ID == u.Player {
-					c.state.Opponents[i].ShopTier = u.ShopTier
+					if u.ShopTier != 0 {
+						c.state.Opponents[i].ShopTier = u.ShopTier
+					}
+					c.state.Opponents[i].Ready = u.Ready
 					break
 				}
 			}
@@ -253,7 +265,7 @@ func (c *GameClient) GameResult() *api.GameResult {
 	return c.state.GameResult
 }
 
-// PlayerList returns all players (including self) sorted by HP descending.
+// PlayerList returns all players (including self) sorted by HP plus armor descending.
 func (c *GameClient) PlayerList() []ui.PlayerEntry {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -270,23 +282,27 @@ func (c *GameClient) PlayerList() []ui.PlayerEntry {
 	list = append(list, ui.PlayerEntry{
 		ID:            p.ID,
 		HP:            p.HP,
+		Armor:         p.Armor,
 		ShopTier:      p.ShopTier,
 		CombatResults: c.state.CombatResults,
 		TopTribe:      selfTribe,
 		TopTribeCount: selfCount,
+		Ready:         p.Ready,
 	})
 	for _, o := range c.state.Opponents {
 		list = append(list, ui.PlayerEntry{
 			ID:            o.ID,
 			HP:            o.HP,
+			Armor:         o.Armor,
 			ShopTier:      o.ShopTier,
 			CombatResults: o.CombatResults,
 			TopTribe:      o.TopTribe,
 			TopTribeCount: o.TopTribeCount,
+			Ready:         o.Ready,
 		})
 	}
 	slices.SortFunc(list, func(a, b ui.PlayerEntry) int {
-		return b.HP - a.HP
+		return (b.HP + b.Armor) - (a.HP + a.Armor)
 	})
 	return list
 }
@@ -301,11 +317,13 @@ func (c *GameClient) SellMinion(boardIndex int) error {
 	return c.send(api.ActionSellMinion, api.SellMinion{BoardIndex: boardIndex})
 }
 
-// PlaceMinion sends a place minion action.
-func (c *GameClient) PlaceMinion(handIndex, boardPosition int) error {
+// PlaceMinion sends a place minion action. targetIndex is the board index of
+// the Battlecry target, or -1 for none.
+func (c *GameClient) PlaceMinion(handIndex, boardPosition, targetIndex int) error {
 	return c.send(api.ActionPlaceMinion, api.PlaceMinion{
 		HandIndex:     handIndex,
 		BoardPosition: boardPosition,
+		TargetIndex:   optionalIndex(targetIndex),
 	})
 }
 
@@ -347,9 +365,18 @@ func (c *GameClient) Discovers() []api.Card {
 	return c.state.Discovers
 }
 
-// PlaySpell sends a play spell action.
-func (c *GameClient) PlaySpell(handIndex int) error {
-	return c.send(api.ActionPlaySpell, api.PlaySpell{HandIndex: handIndex})
+// PlaySpell sends a play spell action. targetIndex is the board index of the
+// chosen minion, or -1 for spells without a target.
+func (c *GameClient) PlaySpell(handIndex, targetIndex int) error {
+	return c.send(api.ActionPlaySpell, api.PlaySpell{HandIndex: handIndex, TargetIndex: optionalIndex(targetIndex)})
+}
+
+// optionalIndex returns idx for an optional board index, nil if negative.
+func optionalIndex(idx int) *int {
+	if idx < 0 {
+		return nil
+	}
+	return &idx
 }
 
 // DiscoverPick sends a discover pick action.
@@ -357,6 +384,47 @@ func (c *GameClient) DiscoverPick(index int) error {
 	return c.send(api.ActionDiscoverPick, api.DiscoverPick{Index: index})
 }
 
+// Tribes returns the tribes in the lobby's card pool.
+func (c *GameClient) Tribes() game.Tribes {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.state == nil {
+		return 0
+	}
+	return c.state.Tribes
+}
+
+// HeroOffers returns the heroes offered during hero selection, or nil if none are pending.
+func (c *GameClient) HeroOffers() []api.Hero {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.state == nil {
+		return nil
+	}
+	return c.state.HeroOffers
+}
+
+// PickHero sends a pick hero action.
+func (c *GameClient) PickHero(index int) error {
+	return c.send(api.ActionPickHero, api.PickHero{Index: index})
+}
+
+// UseHeroPower sends a use hero power action. targetIndex is the board index
+// of the chosen minion, or -1 for powers without a target.
+func (c *GameClient) UseHeroPower(targetIndex int) error {
+	return c.send(api.ActionUseHeroPower, api.UseHeroPower{TargetIndex: optionalIndex(targetIndex)})
+}
+
+// EndTurn sends an end turn action. Any later action takes it back.
+func (c *GameClient) EndTurn() error {
+	return c.send(api.ActionEndTurn, nil)
+}
+
+// Follow switches a spectator client to another player of the lobby.
+func (c *GameClient) Follow(player game.PlayerID) error {
+	return c.send(api.ActionFollow, api.Follow{Player: player})
+}
+
 // DrainOpponentUpdates returns and clears pending opponent updates.
 func (c *GameClient) DrainOpponentUpdates() []api.OpponentUpdate {
 	c.mu.Lock()
@@ -61,14 +61,14 @@ func main() {
 
 	var showMenu func()
 
-	connectAndPlay := func(p *widget.Popup, player game.PlayerID, lobbyID string) {
+	connectAndPlay := func(p *widget.Popup, player game.PlayerID, lobbyID, token string) {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
 		p.SetMessage("Connecting to server...")
 		slog.Info("connecting", "player", player, "lobby", lobbyID)
 
-		gc, err := client.NewGameClient(ctx, cfg.Server.Addr, player, lobbyID, cfg.Server.Proxy)
+		gc, err := client.NewGameClient(ctx, cfg.Server.Addr, token, cfg.Server.Proxy)
 		if err != nil {
 			slog.Error("connection failed", "error", err)
 			p.SetTitle("Error")
@@ -106,7 +106,19 @@ func main() {
 		app.ShowOverlay(p)
 
 		go func() {
-			connectAndPlay(p, player, lobbyID)
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			token, err := httpClient.JoinLobby(ctx, lobbyID, player)
+			if err != nil {
+				slog.Error("join lobby failed", "error", err)
+				p.SetTitle("Error")
+				p.SetMessage(err.Error())
+				p.ShowButton("Close", func() { app.HideOverlay() })
+				return
+			}
+
+			connectAndPlay(p, player, lobbyID, token)
 		}()
 	}
 
@@ -120,7 +132,7 @@ func main() {
 
 			slog.Info("creating lobby", "player", player, "size", lobbySize)
 
-			lobbyID, err := httpClient.CreateLobby(ctx, lobbySize)
+			lobbyID, token, err := httpClient.CreateLobby(ctx, player, lobbySize)
 			if err != nil {
 				slog.Error("create lobby failed", "error", err)
 				p.SetTitle("Error")
@@ -130,7 +142,7 @@ func main() {
 			}
 
 			slog.Info("lobby created", "lobby", lobbyID)
-			connectAndPlay(p, player, lobbyID)
+			connectAndPlay(p, player, lobbyID, token)
 		}()
 	}
 
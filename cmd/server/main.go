@@ -2,12 +2,14 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
 	"flag"
 	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/ysomad/gigabg/game"
 	"github.com/ysomad/gigabg/game/catalog"
@@ -26,6 +28,9 @@ func main() {
 func run(ctx context.Context) error {
 	port := flag.Int("port", 8080, "server port")
 	devLobby := flag.String("dev-lobby", "", "create a 2-player dev lobby with this ID on start")
+	sessionKey := flag.String("session-key", "", "key signing player session tokens, random if empty")
+	dumpCombats := flag.String("dump-combats", "", "write every combat pairing as a cmd/simulate snapshot to this dir")
+	spectatorDelay := flag.Duration("spectator-delay", 90*time.Second, "how long spectators see the game behind the players, at least the longest recruit phase")
 	flag.Parse()
 
 	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug})))
@@ -39,15 +44,25 @@ func run(ctx context.Context) error {
 	}
 
 	memstore := lobby.NewMemoryStore()
+	key := []byte(*sessionKey)
+	if len(key) == 0 {
+		// Lobbies live in memory, so tokens don't need to outlive the process.
+		key = make([]byte, 32)
+		rand.Read(key)
+	}
+
+	gameServer := server.New(memstore, cards, cards, key)
+	if err := gameServer.SetSpectatorDelay(*spectatorDelay); err != nil {
+		return err
+	}
+	gameServer.SetCombatDumpDir(*dumpCombats)
 
 	if *devLobby != "" {
-		if err := createDevLobby(memstore, cards, *devLobby); err != nil {
+		if err := createDevLobby(gameServer, cards, cards, *devLobby); err != nil {
 			return fmt.Errorf("dev lobby: %w", err)
 		}
 	}
 
-	gameServer := server.New(memstore, cards)
-
 	srv := httpserver.New(ctx, gameServer, httpserver.WithPort(*port))
 
 	select {
@@ -64,13 +79,14 @@ func run(ctx context.Context) error {
 	return nil
 }
 
-func createDevLobby(store *lobby.MemoryStore, cards game.CardCatalog, id string) error {
-	l, err := lobby.New(cards, 2)
+func createDevLobby(srv *server.Server, cards game.CardCatalog, heroes game.HeroCatalog, id string) error {
+	l, err := lobby.New(cards, heroes, 2, 0)
 	if err != nil {
 		return err
 	}
 	l.SetID(id)
-	if err := store.CreateLobby(l); err != nil {
+	if err := srv.AddLobby(l); err != nil {
+		l.Close()
 		return err
 	}
 	slog.Info("dev lobby created", "id", id)
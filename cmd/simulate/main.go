@@ -0,0 +1,95 @@
+// Command simulate estimates the odds of a combat by running it many times
+// with the real combat engine.
+//
+// Input is a JSON api.CombatSnapshot read from -in or stdin, with base stats
+// and keywords (auras are applied by the simulator):
+//
+//	{"player_board": [{"template": "...", "attack": 2, "health": 3}], "opponent_board": [...]}
+//
+// The server writes a snapshot of every real fight when run with -dump-combats.
+package main
+
+import (
+	json "encoding/json/v2"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"math/rand/v2"
+	"os"
+	"slices"
+
+	"github.com/ysomad/gigabg/api"
+	"github.com/ysomad/gigabg/game"
+	"github.com/ysomad/gigabg/game/catalog"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	in := flag.String("in", "", "combat snapshot JSON file (default stdin)")
+	runs := flag.Int("runs", 10000, "number of simulated combats")
+	seed := flag.Uint64("seed", 0, "base RNG seed (0 = random)")
+	flag.Parse()
+
+	if *runs <= 0 {
+		return fmt.Errorf("runs must be positive, got %d", *runs)
+	}
+	if *seed == 0 {
+		*seed = rand.Uint64() //nolint:gosec // game logic, not crypto
+	}
+
+	var r io.Reader = os.Stdin
+	if *in != "" {
+		f, err := os.Open(*in)
+		if err != nil {
+			return fmt.Errorf("open input: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var snap api.CombatSnapshot
+	if err := json.UnmarshalRead(r, &snap); err != nil {
+		return fmt.Errorf("decode snapshot: %w", err)
+	}
+
+	cards, err := catalog.New()
+	if err != nil {
+		return fmt.Errorf("card catalog: %w", err)
+	}
+
+	player, opponent, err := snap.SimSides(cards)
+	if err != nil {
+		return err
+	}
+
+	res := game.Simulate(player, opponent, cards, *runs, *seed)
+	report(os.Stdout, res, *seed)
+	return nil
+}
+
+func report(w io.Writer, res game.SimResult, seed uint64) {
+	fmt.Fprintf(w, "runs: %d (seed %d)\n", res.Runs, seed)
+	fmt.Fprintf(w, "win:  %5.1f%%\n", res.WinRate()*100)
+	fmt.Fprintf(w, "tie:  %5.1f%%\n", res.TieRate()*100)
+	fmt.Fprintf(w, "loss: %5.1f%%\n", res.LossRate()*100)
+
+	printDamage(w, "damage dealt", res.DamageDealt, res.Runs)
+	printDamage(w, "damage taken", res.DamageTaken, res.Runs)
+}
+
+func printDamage(w io.Writer, title string, dist map[int]int, runs int) {
+	if len(dist) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\n%s:\n", title)
+	for _, dmg := range slices.Sorted(maps.Keys(dist)) {
+		fmt.Fprintf(w, "  %2d: %5.1f%%\n", dmg, float64(dist[dmg])/float64(runs)*100)
+	}
+}
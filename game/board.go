@@ -63,10 +63,10 @@ func (b Board) HasLivingAttacker() bool {
 	return false
 }
 
-// PickDefender picks a random alive defender.
+// PickDefender picks a random alive defender using rng.
 // Taunt minions are prioritized. Stealth minions cannot be targeted — if only
 // Stealth minions remain, returns nil (attacker's turn is skipped).
-func (b Board) PickDefender() *Minion {
+func (b Board) PickDefender(rng *rand.Rand) *Minion {
 	var taunt []*Minion
 	for _, m := range b.minions {
 		if m.IsAlive() && m.HasKeyword(KeywordTaunt) {
@@ -74,7 +74,7 @@ func (b Board) PickDefender() *Minion {
 		}
 	}
 	if len(taunt) > 0 {
-		return taunt[rand.IntN(len(taunt))] //nolint:gosec // game logic, not crypto
+		return taunt[rng.IntN(len(taunt))]
 	}
 
 	var targets []*Minion
@@ -84,7 +84,7 @@ func (b Board) PickDefender() *Minion {
 		}
 	}
 	if len(targets) > 0 {
-		return targets[rand.IntN(len(targets))] //nolint:gosec // game logic, not crypto
+		return targets[rng.IntN(len(targets))]
 	}
 
 	return nil
@@ -118,6 +118,7 @@ func (b *Board) PlaceMinion(m *Minion, pos int) {
 		pos = len(b.minions)
 	}
 	b.minions = append(b.minions[:pos], append([]*Minion{m}, b.minions[pos:]...)...)
+	b.RecomputeAuras()
 }
 
 // RemoveMinion removes and returns the minion at the given index.
@@ -128,9 +129,28 @@ func (b *Board) RemoveMinion(i int) *Minion {
 	}
 	m := b.minions[i]
 	b.minions = append(b.minions[:i], b.minions[i+1:]...)
+	m.leaveBoard()
+	b.RecomputeAuras()
 	return m
 }
 
+// RemoveDead removes all dead minions and returns them in board order.
+func (b *Board) RemoveDead() []*Minion {
+	var dead []*Minion
+	n := 0
+	for _, m := range b.minions {
+		if !m.IsAlive() {
+			dead = append(dead, m)
+			continue
+		}
+		b.minions[n] = m
+		n++
+	}
+	b.minions = b.minions[:n]
+	b.RecomputeAuras()
+	return dead
+}
+
 // TopTribeOf returns the most common non-neutral tribe on the board and its count.
 func (b Board) TopTribeOf() (Tribe, int) {
 	tribes := make([]Tribes, len(b.minions))
@@ -161,5 +181,64 @@ func (b *Board) Reorder(order []int) error {
 	}
 
 	b.minions = reordered
+	b.RecomputeAuras()
 	return nil
 }
+
+// RecomputeAuras recalculates aura bonuses of living minions from the auras of
+// living minions on the board. Dead minions awaiting removal keep their stats
+// and provide no auras. A minion never dies from losing an aura.
+func (b *Board) RecomputeAuras() {
+	alive := make([]bool, len(b.minions))
+	for i, m := range b.minions {
+		alive[i] = m.IsAlive()
+		if alive[i] {
+			m.resetAura()
+		}
+	}
+
+	for i, src := range b.minions {
+		if !alive[i] {
+			continue
+		}
+		for _, a := range src.auras {
+			for _, j := range b.auraTargets(i, a.Scope) {
+				if alive[j] && a.Filter.Matches(b.minions[j], src) {
+					b.minions[j].applyAura(a)
+				}
+			}
+		}
+	}
+
+	for i, m := range b.minions {
+		if alive[i] && !m.IsAlive() {
+			m.health = 1 - m.auraHealth
+		}
+	}
+}
+
+// auraTargets returns board indexes affected by an aura of the minion at i.
+// The source never buffs itself.
+func (b *Board) auraTargets(i int, scope AuraScope) []int {
+	switch scope {
+	case AuraScopeAllFriendly:
+		res := make([]int, 0, len(b.minions)-1)
+		for j := range b.minions {
+			if j != i {
+				res = append(res, j)
+			}
+		}
+		return res
+	case AuraScopeAdjacent:
+		var res []int
+		if i > 0 {
+			res = append(res, i-1)
+		}
+		if i < len(b.minions)-1 {
+			res = append(res, i+1)
+		}
+		return res
+	default:
+		return nil
+	}
+}
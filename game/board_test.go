@@ -0,0 +1,48 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoard_RecomputeAuras(t *testing.T) {
+	t.Parallel()
+
+	leader := NewMinion(&testTemplate{
+		id: "leader", tier: Tier2, attack: 1, health: 1,
+		auras: []Aura{
+			{Scope: AuraScopeAdjacent, Attack: 1, Health: 1},
+			{Scope: AuraScopeAllFriendly, Filter: TargetFilter{Tribe: TribeMurloc}, Keyword: KeywordTaunt},
+		},
+	})
+	murloc := testMinion(t, "murloc", NewTribes(TribeMurloc), Tier1)
+	beast := testMinion(t, "beast", NewTribes(TribeBeast), Tier1)
+	far := testMinion(t, "far", NewTribes(TribeMurloc), Tier1)
+
+	b := testBoard(t, murloc, leader, beast, far)
+
+	assert.Equal(t, 2, murloc.Attack())
+	assert.Equal(t, 2, beast.Health())
+	assert.Equal(t, 1, far.Attack(), "not adjacent")
+	assert.Equal(t, 1, leader.Attack(), "source doesn't buff itself")
+	assert.True(t, murloc.HasKeyword(KeywordTaunt))
+	assert.True(t, far.HasKeyword(KeywordTaunt))
+	assert.False(t, beast.HasKeyword(KeywordTaunt))
+
+	// Reorder: leader moves next to far.
+	require.NoError(t, b.Reorder([]int{0, 2, 1, 3}))
+	assert.Equal(t, 1, murloc.Attack())
+	assert.Equal(t, 2, beast.Attack())
+	assert.Equal(t, 2, far.Attack())
+
+	// Damage eating into aura health; losing the aura doesn't kill.
+	far.TakeDamage(1)
+	require.True(t, far.IsAlive())
+	b.RemoveMinion(b.IndexOf(leader))
+	assert.Equal(t, 1, far.Health())
+	assert.Equal(t, 1, far.Attack())
+	assert.False(t, far.HasKeyword(KeywordTaunt))
+	assert.Zero(t, leader.AuraAttack())
+}
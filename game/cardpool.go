@@ -40,18 +40,41 @@ type CardCatalog interface {
 	ByKindTierTribe(kind CardKind, tier Tier, tribe Tribe) []CardTemplate // tribe 0 = any
 }
 
+// CardTribes returns the tribes that have minions of their own in the catalog.
+// All-tribe minions don't count towards any tribe.
+func CardTribes(cards CardCatalog) Tribes {
+	var res Tribes
+	for tier := Tier1; tier <= Tier6; tier++ {
+		for _, t := range cards.ByKindTierTribe(CardKindMinion, tier, 0) {
+			if t.Tribes() != TribeAll {
+				res |= t.Tribes()
+			}
+		}
+	}
+	return res
+}
+
 // CardPool manages a finite pool of cards shared across all players in a lobby.
 type CardPool struct {
 	cards      CardCatalog
+	tribes     Tribes                  // tribes whose minions are in the pool
 	quantities map[string]int          // template ID → available copies
 	byTier     map[Tier][]CardTemplate // pool templates indexed by tier
 }
 
-// NewCardPool creates a new card pool with finite quantities per template.
-// Copies are scaled from 8-player base values proportionally to players.
+// NewCardPool creates a new card pool with minions of every tribe.
 func NewCardPool(cards CardCatalog, players int) *CardPool {
+	return NewTribeCardPool(cards, players, TribeAll)
+}
+
+// NewTribeCardPool creates a new card pool with finite quantities per template.
+// Copies are scaled from 8-player base values proportionally to players.
+// Only neutral minions and minions with at least one of the given tribes are
+// included; spells are always included.
+func NewTribeCardPool(cards CardCatalog, players int, tribes Tribes) *CardPool {
 	pool := &CardPool{
 		cards:      cards,
+		tribes:     tribes,
 		quantities: make(map[string]int),
 		byTier:     make(map[Tier][]CardTemplate),
 	}
@@ -63,23 +86,14 @@ func NewCardPool(cards CardCatalog, players int) *CardPool {
 		mCopies := scaleCopies(mc[tier], players)
 		sCopies := scaleCopies(sc[tier], players)
 
-		minions := cards.ByKindTierTribe(CardKindMinion, tier, 0)
-		for _, tmpl := range minions {
-			pool.quantities[tmpl.ID()] = mCopies
-			pool.byTier[tier] = append(pool.byTier[tier], tmpl)
-		}
-
-		spells := cards.ByKindTierTribe(CardKindSpell, tier, 0)
-		for _, tmpl := range spells {
-			pool.quantities[tmpl.ID()] = sCopies
-			pool.byTier[tier] = append(pool.byTier[tier], tmpl)
-		}
+		minions := pool.add(cards.ByKindTierTribe(CardKindMinion, tier, 0), tier, mCopies)
+		spells := pool.add(cards.ByKindTierTribe(CardKindSpell, tier, 0), tier, sCopies)
 
 		slog.Debug("card pool",
 			"tier", tier,
-			"minions", len(minions),
+			"minions", minions,
 			"minion_copies", mCopies,
-			"spells", len(spells),
+			"spells", spells,
 			"spell_copies", sCopies,
 		)
 	}
@@ -87,6 +101,28 @@ func NewCardPool(cards CardCatalog, players int) *CardPool {
 	return pool
 }
 
+// add puts copies of templates allowed by the pool tribes into the tier.
+// Multi-tribe templates listed more than once are added once.
+// Returns the number of templates added.
+func (p *CardPool) add(templates []CardTemplate, tier Tier, copies int) int {
+	var n int
+	for _, tmpl := range templates {
+		if _, ok := p.quantities[tmpl.ID()]; ok {
+			continue
+		}
+		if t := tmpl.Tribes(); t != 0 && !t.HasAny(p.tribes) {
+			continue
+		}
+		p.quantities[tmpl.ID()] = copies
+		p.byTier[tier] = append(p.byTier[tier], tmpl)
+		n++
+	}
+	return n
+}
+
+// Tribes returns the tribes whose minions are in the pool.
+func (p *CardPool) Tribes() Tribes { return p.tribes }
+
 // Roll returns a random selection of cards for the shop, removing them from the pool.
 func (p *CardPool) Roll(maxTier Tier, count int) []Card {
 	var templates []CardTemplate
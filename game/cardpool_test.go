@@ -32,3 +32,44 @@ func Test_scaleCopies(t *testing.T) {
 		})
 	}
 }
+
+// poolCatalog lists templates by kind and tier, repeating multi-tribe
+// templates once per tribe like the real catalog does.
+type poolCatalog []CardTemplate
+
+func (c poolCatalog) ByTemplateID(string) CardTemplate { return nil }
+
+func (c poolCatalog) ByKindTierTribe(kind CardKind, tier Tier, _ Tribe) []CardTemplate {
+	var res []CardTemplate
+	for _, t := range c {
+		if t.Kind() != kind || t.Tier() != tier {
+			continue
+		}
+		for range max(t.Tribes().Len(), 1) {
+			res = append(res, t)
+		}
+	}
+	return res
+}
+
+func TestNewTribeCardPool(t *testing.T) {
+	t.Parallel()
+
+	cards := poolCatalog{
+		&testTemplate{id: "beast", tier: Tier1, tribes: NewTribes(TribeBeast)},
+		&testTemplate{id: "demon", tier: Tier1, tribes: NewTribes(TribeDemon)},
+		&testTemplate{id: "neutral", tier: Tier1},
+		&testTemplate{id: "beast_demon", tier: Tier1, tribes: NewTribes(TribeBeast, TribeDemon)},
+		&testTemplate{id: "spell", kind: CardKindSpell, tier: Tier1},
+	}
+
+	pool := NewTribeCardPool(cards, 8, NewTribes(TribeBeast))
+
+	var ids []string
+	for _, tmpl := range pool.byTier[Tier1] {
+		ids = append(ids, tmpl.ID())
+	}
+	assert.ElementsMatch(t, []string{"beast", "neutral", "beast_demon", "spell"}, ids)
+	assert.Equal(t, NewTribes(TribeBeast), pool.Tribes())
+	assert.Equal(t, NewTribes(TribeBeast, TribeDemon), CardTribes(cards))
+}
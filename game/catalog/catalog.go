@@ -3,11 +3,16 @@ package catalog
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/ysomad/gigabg/game"
 )
 
-var _ game.CardCatalog = (*Catalog)(nil)
+var (
+	_ game.CardCatalog = (*Catalog)(nil)
+	_ game.HeroCatalog = (*Catalog)(nil)
+)
 
 type cardSet struct {
 	tribes game.Tribes
@@ -23,6 +28,8 @@ type Catalog struct {
 	byTribe         map[game.Tribe][]game.CardTemplate
 	byTier          map[game.Tier][]game.CardTemplate
 	byKindTierTribe map[game.CardKind]map[game.Tier]map[game.Tribe][]game.CardTemplate
+	heroes          map[string]game.HeroTemplate
+	heroList        []game.HeroTemplate // sorted by ID
 }
 
 // New loads and indexes all card templates.
@@ -64,6 +71,7 @@ func New() (*Catalog, error) {
 		byTribe:         make(map[game.Tribe][]game.CardTemplate),
 		byTier:          make(map[game.Tier][]game.CardTemplate),
 		byKindTierTribe: make(map[game.CardKind]map[game.Tier]map[game.Tribe][]game.CardTemplate),
+		heroes:          make(map[string]game.HeroTemplate),
 	}
 
 	// Shop cards go into all + indexes.
@@ -89,6 +97,18 @@ func New() (*Catalog, error) {
 		}
 	}
 
+	for id, h := range heroes() {
+		h._id = id
+		if err := h.validate(); err != nil {
+			return nil, fmt.Errorf("'%s' has invalid hero: %w", id, err)
+		}
+		c.heroes[id] = h
+		c.heroList = append(c.heroList, h)
+	}
+	slices.SortFunc(c.heroList, func(a, b game.HeroTemplate) int {
+		return strings.Compare(a.ID(), b.ID())
+	})
+
 	return c, nil
 }
 
@@ -174,3 +194,13 @@ func (c *Catalog) ByKindTierTribe(kind game.CardKind, tier game.Tier, tribe game
 	}
 	return res
 }
+
+// Heroes returns all hero templates sorted by ID.
+func (c *Catalog) Heroes() []game.HeroTemplate {
+	return slices.Clone(c.heroList)
+}
+
+// ByHeroID returns a hero template by ID.
+func (c *Catalog) ByHeroID(id string) game.HeroTemplate {
+	return c.heroes[id]
+}
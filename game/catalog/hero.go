@@ -0,0 +1,161 @@
+package catalog
+
+import (
+	"slices"
+
+	"github.com/ysomad/gigabg/game"
+	"github.com/ysomad/gigabg/pkg/errors"
+)
+
+const (
+	errHeroHealthNotPositive errors.Error = "hero health must be positive"
+	errHeroNegativeArmor     errors.Error = "negative armor"
+	errHeroPowerNoName       errors.Error = "hero power name is empty"
+	errHeroPowerNoCost       errors.Error = "active hero power must have cost"
+	errHeroPowerNoEffects    errors.Error = "active hero power must have hero power effects"
+)
+
+const heroHP = 30 // starting health of most heroes
+
+var _ game.HeroTemplate = (*hero)(nil)
+
+type hero struct {
+	// predefined in runtime
+	_id string
+
+	name  string
+	hp    int
+	armor int
+	power game.HeroPower
+}
+
+func (h *hero) ID() string   { return h._id }
+func (h *hero) Name() string { return h.name }
+func (h *hero) HP() int      { return h.hp }
+func (h *hero) Armor() int   { return h.armor }
+func (h *hero) Power() game.HeroPower {
+	p := h.power
+	p.Effects = slices.Clone(p.Effects)
+	return p
+}
+
+func (h *hero) validate() error {
+	if h.name == "" {
+		return errEmptyName
+	}
+	if h.hp <= 0 {
+		return errHeroHealthNotPositive
+	}
+	if h.armor < 0 {
+		return errHeroNegativeArmor
+	}
+	if h.power.Name == "" {
+		return errHeroPowerNoName
+	}
+	if h.power.Passive {
+		return nil
+	}
+	if h.power.Cost <= 0 {
+		return errHeroPowerNoCost
+	}
+	for range game.EffectsByTrigger(h.power.Effects, game.TriggerHeroPower) {
+		return nil
+	}
+	return errHeroPowerNoEffects
+}
+
+// heroes returns all hero templates.
+func heroes() map[string]*hero {
+	return map[string]*hero{
+		"george_the_fallen": {
+			name:  "George the Fallen",
+			hp:    heroHP,
+			armor: 2,
+			power: game.HeroPower{
+				Name:        "Boon of Light",
+				Description: "Give a friendly minion Divine Shield.",
+				Cost:        3,
+				Effects: []game.TriggeredEffect{
+					{Trigger: game.TriggerHeroPower, Effect: &game.GiveKeyword{
+						Target:  game.Target{Type: game.TargetFriendlySelected},
+						Keyword: game.KeywordDivineShield,
+					}},
+				},
+			},
+		},
+		"the_lich_king": {
+			name:  "The Lich King",
+			hp:    heroHP,
+			armor: 4,
+			power: game.HeroPower{
+				Name:        "Reborn Rites",
+				Description: "Give a friendly minion Reborn.",
+				Cost:        2,
+				Effects: []game.TriggeredEffect{
+					{Trigger: game.TriggerHeroPower, Effect: &game.GiveKeyword{
+						Target:  game.Target{Type: game.TargetFriendlySelected},
+						Keyword: game.KeywordReborn,
+					}},
+				},
+			},
+		},
+		"lord_jaraxxus": {
+			name:  "Lord Jaraxxus",
+			hp:    heroHP,
+			armor: 6,
+			power: game.HeroPower{
+				Name:        "Bloodfury",
+				Description: "Give your Demons +1/+1.",
+				Cost:        1,
+				Effects: []game.TriggeredEffect{
+					{Trigger: game.TriggerHeroPower, Effect: &game.BuffStats{
+						Target: game.Target{
+							Type:   game.TargetAllFriendly,
+							Filter: game.TargetFilter{Tribe: game.TribeDemon},
+						},
+						Attack: 1,
+						Health: 1,
+					}},
+				},
+			},
+		},
+		"pyramad": {
+			name:  "Pyramad",
+			hp:    heroHP,
+			armor: 5,
+			power: game.HeroPower{
+				Name:        "Brick by Brick",
+				Description: "Give a random friendly minion +4 Health.",
+				Cost:        1,
+				Effects: []game.TriggeredEffect{
+					{Trigger: game.TriggerHeroPower, Effect: &game.BuffStats{
+						Target: game.Target{Type: game.TargetRandomFriendly},
+						Health: 4,
+					}},
+				},
+			},
+		},
+		"trade_prince_gallywix": {
+			name:  "Trade Prince Gallywix",
+			hp:    heroHP,
+			armor: 3,
+			power: game.HeroPower{
+				Name:        "Smart Savings",
+				Description: "Passive. At the start of your turn, gain 1 Gold.",
+				Passive:     true,
+				Effects: []game.TriggeredEffect{
+					{Trigger: game.TriggerStartOfTurn, Effect: &game.GainGold{Amount: 1}},
+				},
+			},
+		},
+		"patchwerk": {
+			name: "Patchwerk",
+			hp:   heroHP + 15,
+			power: game.HeroPower{
+				Name:        "All Patched Up",
+				Description: "Passive. Start with 15 extra Health.",
+				Passive:     true,
+			},
+		},
+	}
+}
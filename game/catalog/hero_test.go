@@ -0,0 +1,55 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ysomad/gigabg/game"
+)
+
+func Test_hero_validate(t *testing.T) {
+	t.Parallel()
+	gold := []game.TriggeredEffect{{Trigger: game.TriggerHeroPower, Effect: &game.GainGold{Amount: 1}}}
+	tests := []struct {
+		name      string
+		hero      hero
+		assertion assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "valid_active",
+			hero:      hero{name: "Hero", hp: 30, power: game.HeroPower{Name: "Power", Cost: 1, Effects: gold}},
+			assertion: assert.NoError,
+		},
+		{
+			name:      "valid_passive",
+			hero:      hero{name: "Hero", hp: 30, power: game.HeroPower{Name: "Power", Passive: true}},
+			assertion: assert.NoError,
+		},
+		{
+			name:      "no_health",
+			hero:      hero{name: "Hero", power: game.HeroPower{Name: "Power", Passive: true}},
+			assertion: assert.Error,
+		},
+		{
+			name:      "negative_armor",
+			hero:      hero{name: "Hero", hp: 30, armor: -1, power: game.HeroPower{Name: "Power", Passive: true}},
+			assertion: assert.Error,
+		},
+		{
+			name:      "active_no_cost",
+			hero:      hero{name: "Hero", hp: 30, power: game.HeroPower{Name: "Power", Effects: gold}},
+			assertion: assert.Error,
+		},
+		{
+			name:      "active_no_effects",
+			hero:      hero{name: "Hero", hp: 30, power: game.HeroPower{Name: "Power", Cost: 1}},
+			assertion: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.assertion(t, tt.hero.validate())
+		})
+	}
+}
@@ -25,7 +25,9 @@ func spells() map[string]*template {
 			tier:        game.Tier6,
 			cost:        4,
 			effects: []game.TriggeredEffect{
-				{Trigger: game.TriggerSpell, Effect: &game.MakeGolden{}},
+				{Trigger: game.TriggerSpell, Effect: &game.MakeGolden{
+					Target: game.Target{Type: game.TargetFriendlySelected},
+				}},
 			},
 		},
 	}
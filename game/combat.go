@@ -1,6 +1,8 @@
 package game
 
 import (
+	"fmt"
+	"log/slog"
 	"math/rand/v2"
 	"slices"
 )
@@ -32,6 +34,24 @@ func (s *combatSide) nextLivingAttacker() *Minion {
 	return nil
 }
 
+// remove removes the minion at i and keeps nextAttacker pointing at the same minion.
+func (s *combatSide) remove(i int) *Minion {
+	m := s.board.RemoveMinion(i)
+	if m != nil && s.nextAttacker > i {
+		s.nextAttacker--
+	}
+	return m
+}
+
+// insert places m at pos. Minions inserted left of the next attacker don't
+// change who attacks next; a minion inserted at the next attacker's slot attacks next.
+func (s *combatSide) insert(m *Minion, pos int) {
+	s.board.PlaceMinion(m, pos)
+	if pos < s.nextAttacker {
+		s.nextAttacker++
+	}
+}
+
 // Combat simulates an automated battle between two players.
 type Combat struct {
 	attacker     *combatSide
@@ -42,14 +62,46 @@ type Combat struct {
 	player2      PlayerID
 	player1Board Board                 // snapshot with combat IDs
 	player2Board Board                 // snapshot with combat IDs
-	venomKilled map[CombatID]struct{} // killed by venom this attack
+	venomKilled  map[CombatID]struct{} // killed by venom this attack
+	cards        CardCatalog           // template lookup for summons
+	summonPos    int                   // board index for the next deathrattle summon
+	avenge       map[avengeKey]int     // friendly deaths since last Avenge activation
+	origins      map[CombatID]*Minion  // players' real minions by combat ID
+	persistent   map[CombatID]statBuff // permanent buffs to write back after combat
+	seed         uint64                // RNG seed, replays the fight with the same boards
+	rng          *rand.Rand            // every random choice in the fight
 }
 
-// NewCombat creates a combat with cloned boards.
-// Original player boards are never modified.
-func NewCombat(p1, p2 *Player) *Combat {
+// statBuff is an attack/health bonus.
+type statBuff struct {
+	attack int
+	health int
+}
+
+// avengeKey identifies one Avenge effect of a minion in combat.
+type avengeKey struct {
+	minion CombatID
+	effect int // index in the minion's effects
+}
+
+// avengeTrigger is an Avenge effect whose threshold was reached.
+type avengeTrigger struct {
+	minion *Minion
+	effect Effect
+}
+
+// NewCombat creates a combat with cloned boards. Original player boards are
+// only modified by Persistent buffs, written back when the combat ends.
+// All random choices derive from seed, so equal boards and seed replay the same fight.
+func NewCombat(p1, p2 *Player, cards CardCatalog, seed uint64) *Combat {
 	c := &Combat{
+		seed:         seed,
+		rng:          newCombatRand(seed),
+		cards:        cards,
 		nextCombatID: 1,
+		avenge:       make(map[avengeKey]int),
+		origins:      make(map[CombatID]*Minion),
+		persistent:   make(map[CombatID]statBuff),
 		player1:      p1.ID(),
 		player2:      p2.ID(),
 	}
@@ -59,12 +111,14 @@ func NewCombat(p1, p2 *Player) *Combat {
 
 	c.assignCombatIDs(&side1.board)
 	c.assignCombatIDs(&side2.board)
+	c.trackOrigins(side1.board, p1.board)
+	c.trackOrigins(side2.board, p2.board)
 
 	// Snapshot boards before combat mutates them.
 	c.player1Board = side1.board.Clone()
 	c.player2Board = side2.board.Clone()
 
-	if rand.IntN(2) == 1 { //nolint:gosec // game logic, not crypto
+	if c.rng.IntN(2) == 1 {
 		side1, side2 = side2, side1
 	}
 
@@ -73,6 +127,10 @@ func NewCombat(p1, p2 *Player) *Combat {
 	return c
 }
 
+func newCombatRand(seed uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, seed)) //nolint:gosec // game logic, not crypto
+}
+
 func (c *Combat) assignCombatIDs(b *Board) {
 	for i := range b.Len() {
 		b.MinionAt(i).combatID = c.nextCombatID
@@ -80,12 +138,144 @@ func (c *Combat) assignCombatIDs(b *Board) {
 	}
 }
 
+// trackOrigins maps combat IDs of the cloned board to the player's real minions.
+func (c *Combat) trackOrigins(clone, real Board) {
+	for i, m := range clone.minions {
+		c.origins[m.combatID] = real.minions[i]
+	}
+}
+
+// persist records a permanent buff for the player's real minion behind m.
+// Minions created during combat have no origin and keep buffs only for the fight.
+func (c *Combat) persist(m *Minion, attack, health int) {
+	if _, ok := c.origins[m.combatID]; !ok {
+		return
+	}
+	b := c.persistent[m.combatID]
+	b.attack += attack
+	b.health += health
+	c.persistent[m.combatID] = b
+}
+
+// applyPersistent writes permanent buffs back to players' real minions.
+func (c *Combat) applyPersistent() {
+	for id, b := range c.persistent {
+		c.origins[id].Buff(b.attack, b.health)
+	}
+}
+
 func (c *Combat) emit(e CombatEvent) {
 	c.events = append(c.events, e)
 }
 
-// Run executes the full combat and returns per-player results.
+// opponentOf returns the side facing the given side.
+func (c *Combat) opponentOf(side *combatSide) *combatSide {
+	if side == c.attacker {
+		return c.defender
+	}
+	return c.attacker
+}
+
+// ownerOf returns the player whose board holds m.
+func (c *Combat) ownerOf(m *Minion) PlayerID {
+	if c.defender.board.IndexOf(m) >= 0 {
+		return c.defender.player.ID()
+	}
+	return c.attacker.player.ID()
+}
+
+// effectContext returns the context for effects of a minion owned by side.
+func (c *Combat) effectContext(side *combatSide, source *Minion) EffectContext {
+	return EffectContext{
+		Source:        source,
+		Board:         &side.board,
+		OpponentBoard: &c.opponentOf(side).board,
+		Cards:         c.cards,
+		combat:        c,
+		side:          side,
+	}
+}
+
+// applyEffects runs m's effects for the given triggers. Effects only fail on
+// catalog errors, which are logged so a broken card can't stop the fight.
+func (c *Combat) applyEffects(side *combatSide, m *Minion, triggers ...Trigger) {
+	for e := range m.EffectsByTrigger(triggers...) {
+		c.applyEffect(side, m, e)
+	}
+}
+
+func (c *Combat) applyEffect(side *combatSide, m *Minion, e Effect) {
+	if err := e.Apply(c.effectContext(side, m)); err != nil {
+		slog.Error("combat effect", "template", m.TemplateID(), "effect", fmt.Sprintf("%T", e), "error", err)
+	}
+}
+
+// summon places a minion summoned by an effect at summonPos and assigns it a combat ID.
+func (c *Combat) summon(side *combatSide, m *Minion) {
+	if side.board.IsFull() {
+		return
+	}
+
+	pos := min(c.summonPos, side.board.Len())
+	m.combatID = c.nextCombatID
+	c.nextCombatID++
+	auras := auraSnapshot(side)
+	side.insert(m, pos)
+	c.summonPos = pos + 1
+
+	c.emit(SummonEvent{
+		Target:   m.combatID,
+		Owner:    side.player.ID(),
+		Template: m.TemplateID(),
+		Position: pos,
+		Attack:   m.Attack(),
+		Health:   m.Health(),
+		Keywords: m.Keywords(),
+		Golden:   m.IsGolden(),
+	})
+	c.emitAuraChanges(side, auras)
+}
+
+// auraState is the aura bonus part of a minion's stats.
+type auraState struct {
+	attack   int
+	health   int
+	keywords Keywords
+}
+
+// auraSnapshot records aura bonuses of side's minions before the board changes.
+func auraSnapshot(side *combatSide) map[CombatID]auraState {
+	res := make(map[CombatID]auraState, side.board.Len())
+	for _, m := range side.board.minions {
+		res[m.combatID] = auraState{m.auraAttack, m.auraHealth, m.auraKeywords}
+	}
+	return res
+}
+
+// emitAuraChanges emits an AuraEvent for each living minion whose aura bonuses
+// differ from the snapshot. Minions missing from the snapshot had none.
+func (c *Combat) emitAuraChanges(side *combatSide, before map[CombatID]auraState) {
+	for _, m := range side.board.minions {
+		if !m.IsAlive() || before[m.combatID] == (auraState{m.auraAttack, m.auraHealth, m.auraKeywords}) {
+			continue
+		}
+		c.emit(AuraEvent{
+			Target:     m.combatID,
+			Attack:     m.Attack(),
+			Health:     m.Health(),
+			AuraAttack: m.auraAttack,
+			AuraHealth: m.auraHealth,
+			Keywords:   m.Keywords(),
+			Owner:      side.player.ID(),
+		})
+	}
+}
+
+// Run executes the full combat and returns the results of the players in
+// NewCombat order, whichever side attacked last.
 func (c *Combat) Run() (r1, r2 CombatResult) {
+	c.startOfCombat()
+
 	for range maxCombatIterations {
 		if c.attacker.board.LivingCount() == 0 || c.defender.board.LivingCount() == 0 {
 			break
@@ -104,36 +294,66 @@ func (c *Combat) Run() (r1, r2 CombatResult) {
 			continue
 		}
 
-		target := c.defender.board.PickDefender()
+		target := c.defender.board.PickDefender(c.rng)
 		if target == nil {
 			c.swapTurns()
 			continue
 		}
 
 		c.attack(minion, target)
-		c.removeDeadWithEvents(c.attacker)
-		c.removeDeadWithEvents(c.defender)
+		c.resolveDeaths()
 
 		// Windfury: attack a second time if still alive and has a target.
 		if minion.IsAlive() && minion.HasKeyword(KeywordWindfury) {
-			if t2 := c.defender.board.PickDefender(); t2 != nil {
+			if t2 := c.defender.board.PickDefender(c.rng); t2 != nil {
 				c.attack(minion, t2)
-				c.removeDeadWithEvents(c.attacker)
-				c.removeDeadWithEvents(c.defender)
+				c.resolveDeaths()
 			}
 		}
 
 		c.swapTurns()
 	}
 
+	c.applyPersistent()
 	return c.results()
 }
 
+// startOfCombat resolves Start of Combat effects before the first attack,
+// attacker side first, left to right. Deaths resolve after each minion's effects.
+func (c *Combat) startOfCombat() {
+	for _, side := range []*combatSide{c.attacker, c.defender} {
+		// Iterate a snapshot: effects may summon or kill minions on this board.
+		for _, m := range slices.Clone(side.board.minions) {
+			idx := side.board.IndexOf(m)
+			if idx < 0 || !m.IsAlive() {
+				continue
+			}
+
+			fired := false
+			c.summonPos = idx + 1
+			for e := range m.EffectsByTrigger(TriggerStartOfCombat) {
+				if !fired {
+					fired = true
+					c.emit(TriggerEvent{
+						Source:  m.combatID,
+						Trigger: TriggerStartOfCombat,
+						Owner:   side.player.ID(),
+					})
+				}
+				c.applyEffect(side, m, e)
+			}
+			if fired {
+				c.resolveDeaths()
+			}
+		}
+	}
+}
+
 // attack performs simultaneous damage exchange between two minions.
 func (c *Combat) attack(src, dst *Minion) {
 	// Stealth is lost when the minion attacks.
 	if src.HasKeyword(KeywordStealth) {
-		src.RemoveKeyword(KeywordStealth)
+		src.spendKeyword(KeywordStealth)
 		c.emit(RemoveKeywordEvent{
 			Target:  src.combatID,
 			Keyword: KeywordStealth,
@@ -197,7 +417,7 @@ func (c *Combat) applyVenom(src, dst *Minion, hit bool, owner PlayerID) {
 	dst.TakeDamage(dst.Health())
 	c.venomKilled[dst.combatID] = struct{}{}
 
-	src.RemoveKeyword(KeywordVenomous)
+	src.spendKeyword(KeywordVenomous)
 	c.emit(RemoveKeywordEvent{
 		Target:  src.combatID,
 		Keyword: KeywordVenomous,
@@ -213,7 +433,7 @@ func (c *Combat) dealDamage(src, dst *Minion, amount int, owner PlayerID) bool {
 	}
 
 	if dst.HasKeyword(KeywordDivineShield) {
-		dst.RemoveKeyword(KeywordDivineShield)
+		dst.spendKeyword(KeywordDivineShield)
 		c.emit(RemoveKeywordEvent{
 			Source:  src.combatID,
 			Target:  dst.combatID,
@@ -233,8 +453,27 @@ func (c *Combat) dealDamage(src, dst *Minion, amount int, owner PlayerID) bool {
 	return true
 }
 
-// removeDeadWithEvents removes dead minions, emits death events,
-// and handles Reborn (spawns fresh template minion with 1 HP at the same position).
+// resolveDeaths removes dead minions from both sides, attacker first,
+// until deathrattles stop killing anything.
+func (c *Combat) resolveDeaths() {
+	for c.hasDead(c.attacker) || c.hasDead(c.defender) {
+		c.removeDeadWithEvents(c.attacker)
+		c.removeDeadWithEvents(c.defender)
+	}
+}
+
+func (c *Combat) hasDead(side *combatSide) bool {
+	for _, m := range side.board.minions {
+		if !m.IsAlive() {
+			return true
+		}
+	}
+	return false
+}
+
+// removeDeadWithEvents removes dead minions left to right, emits death events,
+// resolves Deathrattles at the dead minion's position and handles Reborn
+// (spawns fresh template minion with 1 HP right of the Deathrattle summons).
 func (c *Combat) removeDeadWithEvents(side *combatSide) {
 	for i := 0; i < side.board.Len(); i++ {
 		m := side.board.MinionAt(i)
@@ -251,32 +490,45 @@ func (c *Combat) removeDeadWithEvents(side *combatSide) {
 			Owner:       side.player.ID(),
 		})
 
-		hasReborn := m.HasKeyword(KeywordReborn)
-		var reborn *Minion
-		if hasReborn {
-			reborn = NewMinion(m.Template())
+		leftOfNext := i < side.nextAttacker
+		auras := auraSnapshot(side)
+		side.remove(i)
+		c.emitAuraChanges(side, auras)
+		n := side.board.Len()
+		avengers := c.countAvenge(side)
+
+		c.summonPos = i
+		c.applyEffects(side, m, TriggerDeathrattle)
+
+		if m.HasKeyword(KeywordReborn) && !side.board.IsFull() {
+			reborn := NewMinion(m.Template())
 			reborn.health = 1
 			reborn.RemoveKeyword(KeywordReborn)
 			reborn.combatID = c.nextCombatID
 			c.nextCombatID++
-		}
-
-		side.board.RemoveMinion(i)
 
-		if hasReborn {
-			side.board.PlaceMinion(reborn, i)
+			pos := min(c.summonPos, side.board.Len())
+			auras := auraSnapshot(side)
+			side.insert(reborn, pos)
 			c.emit(RebornEvent{
 				Target:   reborn.combatID,
 				Owner:    side.player.ID(),
 				Template: reborn.TemplateID(),
+				Position: pos,
 			})
-			// Reborn replaced the dead minion in place, no index adjustment.
-		} else {
-			if side.nextAttacker > i {
-				side.nextAttacker--
-			}
-			i--
+			c.emitAuraChanges(side, auras)
+		}
+
+		// Minions spawned in place of a minion that stood left of the next
+		// attacker must not jump ahead of it.
+		if leftOfNext && side.nextAttacker == i {
+			side.nextAttacker += side.board.Len() - n
 		}
+
+		c.fireAvenge(side, avengers)
+
+		// Re-check index i: it now holds a summoned minion, a reborn or the next minion.
+		i--
 	}
 
 	if n := side.board.Len(); n > 0 {
@@ -286,6 +538,47 @@ func (c *Combat) removeDeadWithEvents(side *combatSide) {
 	}
 }
 
+// countAvenge counts a friendly death for every Avenge effect on side's living
+// minions and returns those that reached their threshold. Reached counters reset,
+// so an Avenge fires again every Threshold deaths.
+func (c *Combat) countAvenge(side *combatSide) []avengeTrigger {
+	var res []avengeTrigger
+	for _, m := range side.board.minions {
+		if !m.IsAlive() {
+			continue
+		}
+		for i, te := range m.effects {
+			if te.Trigger != TriggerAvenge || te.Effect == nil {
+				continue
+			}
+			key := avengeKey{minion: m.combatID, effect: i}
+			c.avenge[key]++
+			if c.avenge[key] < max(te.Threshold, 1) {
+				continue
+			}
+			c.avenge[key] = 0
+			res = append(res, avengeTrigger{minion: m, effect: te.Effect})
+		}
+	}
+	return res
+}
+
+// fireAvenge applies reached Avenge effects whose minion is still alive.
+func (c *Combat) fireAvenge(side *combatSide, avengers []avengeTrigger) {
+	for _, a := range avengers {
+		if !a.minion.IsAlive() {
+			continue
+		}
+		c.emit(TriggerEvent{
+			Source:  a.minion.combatID,
+			Trigger: TriggerAvenge,
+			Owner:   side.player.ID(),
+		})
+		c.summonPos = side.board.IndexOf(a.minion) + 1 // summons appear right of the avenger
+		c.applyEffect(side, a.minion, a.effect)
+	}
+}
+
 // swapTurns alternates which side attacks next.
 func (c *Combat) swapTurns() {
 	c.attacker, c.defender = c.defender, c.attacker
@@ -301,29 +594,24 @@ func (c *Combat) Log() CombatLog {
 	return CombatLog{
 		Player1: c.player1,
 		Player2: c.player2,
+		Seed:    c.seed,
 		Events:  slices.Clone(c.events),
 	}
 }
 
 // results computes per-player CombatResults from the final board states.
 func (c *Combat) results() (CombatResult, CombatResult) {
-	p1 := c.attacker.player.ID()
-	p2 := c.defender.player.ID()
-
-	r1 := CombatResult{Opponent: p2}
-	r2 := CombatResult{Opponent: p1}
-
-	alive1 := c.attacker.board.LivingCount()
-	alive2 := c.defender.board.LivingCount()
+	r1 := CombatResult{Opponent: c.player2}
+	r2 := CombatResult{Opponent: c.player1}
 
+	// Sides swap every attack, so the winner is looked up by side and
+	// reported by player.
 	var winnerSide *combatSide
-	switch {
+	switch alive1, alive2 := c.attacker.board.LivingCount(), c.defender.board.LivingCount(); {
 	case alive1 > 0 && alive2 == 0:
 		winnerSide = c.attacker
-		r1.Winner = p1
 	case alive2 > 0 && alive1 == 0:
 		winnerSide = c.defender
-		r1.Winner = p2
 	default:
 		return r1, r2
 	}
@@ -334,10 +622,13 @@ func (c *Combat) results() (CombatResult, CombatResult) {
 			damage += int(m.Tier())
 		}
 	}
+	r1.Winner = winnerSide.player.ID()
 	r1.Damage = damage
+	r1.RawDamage = damage
 
 	r2.Winner = r1.Winner
 	r2.Damage = r1.Damage
+	r2.RawDamage = r1.RawDamage
 
 	return r1, r2
 }
@@ -0,0 +1,364 @@
+package game
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCatalog is a CardCatalog backed by a map, for token lookups.
+type testCatalog map[string]CardTemplate
+
+func (c testCatalog) ByTemplateID(id string) CardTemplate { return c[id] }
+
+func (c testCatalog) ByKindTierTribe(CardKind, Tier, Tribe) []CardTemplate { return nil }
+
+// testCombat builds a combat between two sides with combat IDs assigned.
+func testCombat(t *testing.T, cards CardCatalog, attacker, defender []*Minion) *Combat {
+	t.Helper()
+	c := &Combat{
+		nextCombatID: 1,
+		rng:          newCombatRand(1),
+		cards:        cards,
+		avenge:       make(map[avengeKey]int),
+		origins:      make(map[CombatID]*Minion),
+		persistent:   make(map[CombatID]statBuff),
+		attacker:     &combatSide{player: NewPlayer(1), board: *testBoard(t, attacker...)},
+		defender:     &combatSide{player: NewPlayer(2), board: *testBoard(t, defender...)},
+	}
+	c.assignCombatIDs(&c.attacker.board)
+	c.assignCombatIDs(&c.defender.board)
+	return c
+}
+
+func eventsOfType[T CombatEvent](events []CombatEvent) []T {
+	var res []T
+	for _, e := range events {
+		if v, ok := e.(T); ok {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+func TestCombat_removeDeadWithEvents_Deathrattle(t *testing.T) {
+	t.Parallel()
+
+	skeleton := &testTemplate{id: "skeleton", tier: Tier1, attack: 1, health: 1}
+	cards := testCatalog{"skeleton": skeleton}
+
+	baron := NewMinion(&testTemplate{
+		id: "baron", tier: Tier4, attack: 5, health: 5,
+		effects: []TriggeredEffect{
+			{Trigger: TriggerDeathrattle, Effect: &SummonMinion{TemplateID: "skeleton"}},
+			{Trigger: TriggerDeathrattle, Effect: &BuffStats{
+				Target: Target{Type: TargetAllFriendly, Filter: TargetFilter{ExcludeSource: true}},
+				Attack: 1,
+				Health: 1,
+			}},
+		},
+	})
+	left := testMinion(t, "left", 0, Tier1)
+	right := testMinion(t, "right", 0, Tier1)
+
+	c := testCombat(t, cards, []*Minion{left, baron, right}, nil)
+	c.attacker.nextAttacker = 2 // right attacks next
+
+	baron.TakeDamage(baron.Health())
+	c.resolveDeaths()
+
+	require.Equal(t, []string{"left", "skeleton", "right"}, minionIDs(c.attacker.board.Minions()))
+	assert.Equal(t, 2, c.attacker.nextAttacker, "summon left of next attacker shifts it")
+
+	summons := eventsOfType[SummonEvent](c.events)
+	require.Len(t, summons, 1)
+	assert.Equal(t, 1, summons[0].Position)
+	assert.Equal(t, "skeleton", summons[0].Template)
+
+	// Skeleton is summoned before the buff, so it gets buffed too.
+	assert.Len(t, eventsOfType[BuffEvent](c.events), 3)
+	assert.Equal(t, 2, c.attacker.board.MinionAt(1).Attack())
+	assert.Equal(t, 2, left.Attack())
+}
+
+func TestCombat_removeDeadWithEvents_SummonBoardFull(t *testing.T) {
+	t.Parallel()
+
+	cards := testCatalog{"skeleton": &testTemplate{id: "skeleton", tier: Tier1, attack: 1, health: 1}}
+	golden := NewGoldenMinion(&testTemplate{
+		id: "baron", tier: Tier4, attack: 5, health: 5,
+		effects: []TriggeredEffect{
+			{Trigger: TriggerDeathrattle, Effect: &SummonMinion{TemplateID: "skeleton"}},
+			{Trigger: TriggerDeathrattle, Effect: &SummonMinion{TemplateID: "skeleton"}},
+		},
+		keywords: NewKeywords(KeywordReborn),
+	})
+
+	minions := []*Minion{golden}
+	for range maxBoardSize - 2 {
+		minions = append(minions, testMinion(t, "filler", 0, Tier1))
+	}
+
+	c := testCombat(t, cards, minions, nil)
+	golden.TakeDamage(golden.Health())
+	c.resolveDeaths()
+
+	assert.Equal(t, maxBoardSize, c.attacker.board.Len())
+	first := c.attacker.board.MinionAt(0)
+	assert.True(t, first.IsGolden(), "golden deathrattle summons golden token")
+	assert.Equal(t, 2, first.Attack())
+	assert.Len(t, eventsOfType[SummonEvent](c.events), 2)
+	assert.Empty(t, eventsOfType[RebornEvent](c.events), "no room left for reborn")
+}
+
+func TestCombat_removeDeadWithEvents_Avenge(t *testing.T) {
+	t.Parallel()
+
+	avenger := NewMinion(&testTemplate{
+		id: "avenger", tier: Tier3, attack: 1, health: 10,
+		effects: []TriggeredEffect{
+			{Trigger: TriggerAvenge, Threshold: 2, Effect: &BuffStats{
+				Target: Target{Type: TargetSelf},
+				Attack: 1,
+				Health: 1,
+			}},
+		},
+	})
+	golden := NewGoldenMinion(avenger.Template())
+
+	minions := []*Minion{avenger, golden}
+	for range 5 {
+		minions = append(minions, testMinion(t, "fodder", 0, Tier1))
+	}
+	c := testCombat(t, nil, minions, nil)
+
+	for i := 2; i < len(minions); i++ {
+		minions[i].TakeDamage(1)
+		c.resolveDeaths()
+	}
+
+	// 5 deaths with threshold 2 fire twice.
+	assert.Equal(t, 3, avenger.Attack())
+	assert.Equal(t, 6, golden.Attack(), "golden avenge doubles payload")
+
+	triggers := eventsOfType[TriggerEvent](c.events)
+	require.Len(t, triggers, 4)
+	assert.Equal(t, TriggerAvenge, triggers[0].Trigger)
+	assert.Equal(t, avenger.CombatID(), triggers[0].Source)
+}
+
+func TestCombat_removeDeadWithEvents_RebornAfterSummons(t *testing.T) {
+	t.Parallel()
+
+	cards := testCatalog{"skeleton": &testTemplate{id: "skeleton", tier: Tier1, attack: 1, health: 1}}
+	lich := NewMinion(&testTemplate{
+		id: "lich", tier: Tier3, attack: 2, health: 2,
+		keywords: NewKeywords(KeywordReborn),
+		effects: []TriggeredEffect{
+			{Trigger: TriggerDeathrattle, Effect: &SummonMinion{TemplateID: "skeleton"}},
+			{Trigger: TriggerDeathrattle, Effect: &SummonMinion{TemplateID: "skeleton"}},
+		},
+	})
+	left := testMinion(t, "left", 0, Tier1)
+	right := testMinion(t, "right", 0, Tier1)
+
+	c := testCombat(t, cards, []*Minion{left, lich, right}, nil)
+	lich.TakeDamage(lich.Health())
+	c.resolveDeaths()
+
+	assert.Equal(t, []string{"left", "skeleton", "skeleton", "lich", "right"}, minionIDs(c.attacker.board.Minions()))
+	reborns := eventsOfType[RebornEvent](c.events)
+	require.Len(t, reborns, 1)
+	assert.Equal(t, 3, reborns[0].Position)
+}
+
+func TestCombat_removeDeadWithEvents_AvengeSummonsNextToAvenger(t *testing.T) {
+	t.Parallel()
+
+	cards := testCatalog{"skeleton": &testTemplate{id: "skeleton", tier: Tier1, attack: 1, health: 1}}
+	avenger := NewMinion(&testTemplate{
+		id: "avenger", tier: Tier3, attack: 1, health: 10,
+		effects: []TriggeredEffect{
+			{Trigger: TriggerAvenge, Threshold: 1, Effect: &SummonMinion{TemplateID: "skeleton"}},
+		},
+	})
+	middle := testMinion(t, "middle", 0, Tier1)
+	fodder := testMinion(t, "fodder", 0, Tier1)
+
+	c := testCombat(t, cards, []*Minion{avenger, middle, fodder}, nil)
+	fodder.TakeDamage(fodder.Health())
+	c.resolveDeaths()
+
+	assert.Equal(t, []string{"avenger", "skeleton", "middle"}, minionIDs(c.attacker.board.Minions()))
+	summons := eventsOfType[SummonEvent](c.events)
+	require.Len(t, summons, 1)
+	assert.Equal(t, 1, summons[0].Position)
+}
+
+func TestCombat_AuraDivineShieldStaysPopped(t *testing.T) {
+	t.Parallel()
+
+	guard := NewMinion(&testTemplate{
+		id: "guard", tier: Tier3, attack: 1, health: 5,
+		auras: []Aura{{Scope: AuraScopeAllFriendly, Keyword: KeywordDivineShield}},
+	})
+	shielded := testMinion(t, "shielded", 0, Tier1)
+	fodder := testMinion(t, "fodder", 0, Tier1)
+	enemy := testMinion(t, "enemy", 0, Tier1)
+
+	c := testCombat(t, nil, []*Minion{guard, shielded, fodder}, []*Minion{enemy})
+	require.True(t, shielded.HasKeyword(KeywordDivineShield))
+
+	assert.False(t, c.dealDamage(enemy, shielded, 1, c.attacker.player.ID()), "shield absorbs the hit")
+	assert.False(t, shielded.HasKeyword(KeywordDivineShield))
+
+	// Another death recomputes the auras.
+	fodder.TakeDamage(fodder.Health())
+	c.resolveDeaths()
+
+	assert.False(t, shielded.HasKeyword(KeywordDivineShield), "spent aura shield doesn't come back")
+	assert.Len(t, eventsOfType[RemoveKeywordEvent](c.events), 1)
+}
+
+func TestCombat_startOfCombat(t *testing.T) {
+	t.Parallel()
+
+	cannon := NewMinion(&testTemplate{
+		id: "cannon", tier: Tier2, attack: 0, health: 3,
+		effects: []TriggeredEffect{
+			{Trigger: TriggerStartOfCombat, Effect: &DealDamage{Target: Target{Type: TargetAllEnemy}, Amount: 1}},
+		},
+	})
+	weak := testMinion(t, "weak", 0, Tier1)
+	sturdy := NewMinion(&testTemplate{id: "sturdy", tier: Tier1, attack: 1, health: 5})
+
+	c := testCombat(t, nil, []*Minion{cannon}, []*Minion{weak, sturdy})
+	c.Run()
+
+	require.NotEmpty(t, c.events)
+	trigger, ok := c.events[0].(TriggerEvent)
+	require.True(t, ok, "start of combat fires before the first attack")
+	assert.Equal(t, TriggerStartOfCombat, trigger.Trigger)
+	assert.Equal(t, cannon.CombatID(), trigger.Source)
+
+	firstAttack := slices.IndexFunc(c.events, func(e CombatEvent) bool {
+		_, ok := e.(AttackEvent)
+		return ok
+	})
+	deaths := eventsOfType[DeathEvent](c.events[:firstAttack])
+	require.Len(t, deaths, 1)
+	assert.Equal(t, weak.CombatID(), deaths[0].Target)
+}
+
+func TestCombat_DestroyMinionEmitsDamage(t *testing.T) {
+	t.Parallel()
+
+	assassin := NewMinion(&testTemplate{
+		id: "assassin", tier: Tier3, attack: 0, health: 3,
+		effects: []TriggeredEffect{
+			{Trigger: TriggerStartOfCombat, Effect: &DestroyMinion{Target: Target{Type: TargetAllEnemy}}},
+		},
+	})
+	shielded := NewMinion(&testTemplate{
+		id: "shielded", tier: Tier1, attack: 1, health: 4,
+		keywords: NewKeywords(KeywordDivineShield),
+	})
+
+	c := testCombat(t, nil, []*Minion{assassin}, []*Minion{shielded})
+	c.startOfCombat()
+
+	damage := slices.IndexFunc(c.events, func(e CombatEvent) bool {
+		d, ok := e.(DamageEvent)
+		return ok && d.Target == shielded.CombatID()
+	})
+	death := slices.IndexFunc(c.events, func(e CombatEvent) bool {
+		d, ok := e.(DeathEvent)
+		return ok && d.Target == shielded.CombatID()
+	})
+	require.GreaterOrEqual(t, damage, 0, "destroy is replayed as damage")
+	require.Greater(t, death, damage, "damage comes before the death")
+
+	ev := c.events[damage].(DamageEvent)
+	assert.Equal(t, assassin.CombatID(), ev.Source)
+	assert.Equal(t, 4, ev.Amount)
+	assert.Equal(t, PlayerID(2), ev.Owner)
+}
+
+func TestCombat_Run_PersistentBuffs(t *testing.T) {
+	t.Parallel()
+
+	martyr := NewMinion(&testTemplate{
+		id: "martyr", tier: Tier2, attack: 0, health: 1,
+		keywords: NewKeywords(KeywordTaunt),
+		effects: []TriggeredEffect{
+			{Trigger: TriggerDeathrattle, Effect: &BuffStats{
+				Target: Target{Type: TargetAllFriendly}, Health: 1, Persistent: true,
+			}},
+			{Trigger: TriggerDeathrattle, Effect: &BuffStats{
+				Target: Target{Type: TargetAllFriendly}, Health: 2,
+			}},
+		},
+	})
+	wall := NewMinion(&testTemplate{id: "wall", tier: Tier1, attack: 0, health: 10})
+	brute := NewMinion(&testTemplate{id: "brute", tier: Tier1, attack: 1, health: 100})
+
+	p1 := NewPlayer(1)
+	p1.board.PlaceMinion(martyr, 0)
+	p1.board.PlaceMinion(wall, 1)
+	p2 := NewPlayer(2)
+	p2.board.PlaceMinion(brute, 0)
+
+	c := NewCombat(p1, p2, nil, 1)
+	c.Run()
+
+	assert.Equal(t, 11, wall.Health(), "only the persistent buff sticks")
+	assert.Equal(t, 0, wall.Attack())
+	assert.Equal(t, 1, martyr.Health(), "real minions don't take combat damage")
+	assert.Equal(t, 100, brute.Health())
+}
+
+func TestNewCombat_SeedReplaysFight(t *testing.T) {
+	t.Parallel()
+
+	newPlayer := func(id PlayerID) *Player {
+		p := NewPlayer(id)
+		for i := range 5 {
+			p.board.PlaceMinion(NewMinion(&testTemplate{
+				id: "m", tier: Tier1, attack: 1 + i, health: 2 + i,
+				effects: []TriggeredEffect{
+					{Trigger: TriggerDeathrattle, Effect: &DealDamage{Target: Target{Type: TargetRandomEnemy}, Amount: 1}},
+				},
+			}), i)
+		}
+		return p
+	}
+	run := func(seed uint64) CombatLog {
+		c := NewCombat(newPlayer(1), newPlayer(2), nil, seed)
+		c.Run()
+		return c.Log()
+	}
+
+	first := run(42)
+	assert.Equal(t, uint64(42), first.Seed)
+	assert.Equal(t, first, run(42))
+}
+
+func TestCombat_Run_ResultsInPlayerOrder(t *testing.T) {
+	t.Parallel()
+
+	lastAttackers := make(map[PlayerID]bool)
+	for seed := range uint64(16) {
+		p1 := NewPlayer(1)
+		p1.board.PlaceMinion(testMinion(t, "m", 0, Tier1), 0)
+
+		c := NewCombat(p1, NewPlayer(2), nil, seed)
+		r1, r2 := c.Run()
+		lastAttackers[c.attacker.player.ID()] = true
+
+		assert.Equal(t, CombatResult{Opponent: 2, Winner: 1, Damage: 2, RawDamage: 2}, r1, "seed %d", seed)
+		assert.Equal(t, CombatResult{Opponent: 1, Winner: 1, Damage: 2, RawDamage: 2}, r2, "seed %d", seed)
+	}
+	assert.Len(t, lastAttackers, 2, "both players attack last for some seed")
+}
@@ -7,10 +7,12 @@ const (
 	CombatEventAttack        CombatEventType = iota + 1 // minion initiates attack
 	CombatEventDamage                                   // damage dealt to a minion
 	CombatEventDeath                                    // minion dies and is removed
-	CombatEventBuff                                     // future: stat buff applied
+	CombatEventBuff                                     // stats or keyword granted by an effect
 	CombatEventRemoveKeyword                            // keyword removed from minion
 	CombatEventReborn                                   // minion respawned via Reborn
-	CombatEventTrigger                                  // future: triggered ability fires
+	CombatEventTrigger                                  // triggered ability fires
+	CombatEventSummon                                   // minion summoned by an effect
+	CombatEventAura                                     // aura bonuses of a minion changed
 )
 
 // CombatEvent is implemented by all combat event types.
@@ -24,6 +26,10 @@ var (
 	_ CombatEvent = (*DeathEvent)(nil)
 	_ CombatEvent = (*RemoveKeywordEvent)(nil)
 	_ CombatEvent = (*RebornEvent)(nil)
+	_ CombatEvent = (*SummonEvent)(nil)
+	_ CombatEvent = (*BuffEvent)(nil)
+	_ CombatEvent = (*TriggerEvent)(nil)
+	_ CombatEvent = (*AuraEvent)(nil)
 )
 
 // AttackEvent is emitted when a minion initiates an attack.
@@ -77,6 +83,7 @@ type RebornEvent struct {
 	Target   CombatID `json:"target"`
 	Owner    PlayerID `json:"owner"`
 	Template string   `json:"template"`
+	Position int      `json:"position"` // board index, right of the minion's Deathrattle summons
 }
 
 func (RebornEvent) Type() CombatEventType { return CombatEventReborn }
@@ -84,9 +91,10 @@ func (RebornEvent) Type() CombatEventType { return CombatEventReborn }
 // CombatResult is the outcome of a single combat from one player's perspective.
 // Stored per-player (last 3). Visible to other clients on hover.
 type CombatResult struct {
-	Opponent PlayerID
-	Winner   PlayerID // 0 if tie
-	Damage   int      // damage dealt to loser (0 if tie)
+	Opponent  PlayerID
+	Winner    PlayerID // 0 if tie
+	Damage    int      // damage dealt to loser after the damage cap (0 if tie)
+	RawDamage int      // damage before the damage cap
 }
 
 // CombatLog holds data for combat replay on the client.
@@ -95,5 +103,54 @@ type CombatResult struct {
 type CombatLog struct {
 	Player1 PlayerID
 	Player2 PlayerID
+	Seed    uint64 // combat RNG seed
 	Events  []CombatEvent
 }
+
+// SummonEvent is emitted when an effect summons a minion at Position on the owner's board.
+type SummonEvent struct {
+	Target   CombatID `json:"target"`
+	Owner    PlayerID `json:"owner"`
+	Template string   `json:"template"`
+	Position int      `json:"position"`
+	Attack   int      `json:"attack"`
+	Health   int      `json:"health"`
+	Keywords Keywords `json:"keywords,omitzero"`
+	Golden   bool     `json:"golden,omitzero"`
+}
+
+func (SummonEvent) Type() CombatEventType { return CombatEventSummon }
+
+// BuffEvent is emitted when an effect gives a minion stats and/or a keyword.
+type BuffEvent struct {
+	Target  CombatID `json:"target"`
+	Attack  int      `json:"attack,omitzero"`
+	Health  int      `json:"health,omitzero"`
+	Keyword Keyword  `json:"keyword,omitzero"`
+	Owner   PlayerID `json:"owner"`
+}
+
+func (BuffEvent) Type() CombatEventType { return CombatEventBuff }
+
+// TriggerEvent is emitted when a minion's triggered ability fires, before its effects resolve.
+type TriggerEvent struct {
+	Source  CombatID `json:"source"`
+	Trigger Trigger  `json:"trigger"`
+	Owner   PlayerID `json:"owner"`
+}
+
+func (TriggerEvent) Type() CombatEventType { return CombatEventTrigger }
+
+// AuraEvent is emitted when a minion's aura bonuses change because an aura
+// source entered or left the board. Stats are the resulting effective values.
+type AuraEvent struct {
+	Target     CombatID `json:"target"`
+	Attack     int      `json:"attack"`
+	Health     int      `json:"health"`
+	AuraAttack int      `json:"aura_attack,omitzero"`
+	AuraHealth int      `json:"aura_health,omitzero"`
+	Keywords   Keywords `json:"keywords,omitzero"`
+	Owner      PlayerID `json:"owner"`
+}
+
+func (AuraEvent) Type() CombatEventType { return CombatEventAura }
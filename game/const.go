@@ -8,8 +8,18 @@ const (
 )
 
 const (
-	RecruitDuration = 20 * time.Second
-	CombatDuration  = 5 * time.Second
+	HeroSelectDuration = 20 * time.Second
+
+	recruitBaseDuration = 20 * time.Second // turn 1
+	recruitTurnDuration = 5 * time.Second  // added each later turn
+	MaxRecruitDuration  = 60 * time.Second
+
+	// CombatEventDuration is the animation time per combat event. The
+	// client's combat board derives its attack and death timings from it, an
+	// attack with its damage and death events takes about 1.3s to animate.
+	CombatEventDuration = 450 * time.Millisecond
+	combatBaseDuration  = 2 * time.Second  // boards reveal and last deaths
+	maxCombatDuration   = 60 * time.Second // longer fights play faster on the client
 )
 
 const (
@@ -29,4 +39,6 @@ const (
 
 const discoverCount = 3
 
+const LobbyTribes = 5 // tribes in each lobby's card pool
+
 const TripleRewardID = "triple_reward"
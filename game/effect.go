@@ -2,6 +2,7 @@ package game
 
 import (
 	"errors"
+	"fmt"
 	"iter"
 )
 
@@ -21,6 +22,7 @@ var (
 	_ Effect = (*DealDamage)(nil)
 	_ Effect = (*DestroyMinion)(nil)
 	_ Effect = (*AddCard)(nil)
+	_ Effect = (*GainGold)(nil)
 )
 
 // TargetType defines who the effect targets.
@@ -56,13 +58,19 @@ type Target struct {
 
 // EffectContext provides game state for effect execution.
 type EffectContext struct {
-	Source        *Minion   // minion that triggered the effect
-	Board         *Board    // player's board
-	Hand          *Hand     // player's hand
-	Shop          *Shop     // player's shop (nil during combat)
-	Pool          *CardPool // shared card pool (nil during combat)
-	OpponentBoard *Board    // opponent's board (nil outside combat)
-	Discovers     *[]Card   // discover options output (set by DiscoverCard)
+	Source        *Minion     // minion that triggered the effect
+	Board         *Board      // player's board
+	Hand          *Hand       // player's hand
+	Shop          *Shop       // player's shop (nil during combat)
+	Pool          *CardPool   // shared card pool (nil during combat)
+	OpponentBoard *Board      // opponent's board (nil outside combat)
+	Discovers     *[]Card     // discover options output (set by DiscoverCard)
+	Cards         CardCatalog // template lookup for summoned minions
+	Gold          *int        // player's gold (nil during combat)
+	Selected      *Minion     // friendly minion chosen by the player (TargetFriendlySelected)
+
+	combat *Combat     // running combat (nil outside combat)
+	side   *combatSide // side owning the source (combat only)
 }
 
 func (ctx EffectContext) WithSource(m *Minion) EffectContext {
@@ -79,6 +87,81 @@ func (ctx EffectContext) WithOpponentBoard(b *Board) EffectContext {
 	return ctx
 }
 
+// inCombat reports whether the effect runs during combat.
+func (ctx EffectContext) inCombat() bool { return ctx.OpponentBoard != nil }
+
+// summon places m on the board. In combat the minion is placed where the
+// dead source stood, otherwise to the right of the source.
+func (ctx EffectContext) summon(m *Minion) {
+	if ctx.combat != nil {
+		ctx.combat.summon(ctx.side, m)
+		return
+	}
+	pos := ctx.Board.Len()
+	if i := ctx.Board.IndexOf(ctx.Source); i >= 0 {
+		pos = i + 1
+	}
+	ctx.Board.PlaceMinion(m, pos)
+}
+
+// buff adds stats and an optional keyword to m, emitting a combat event in combat.
+func (ctx EffectContext) buff(m *Minion, attack, health int, kw Keyword) {
+	m.Buff(attack, health)
+	if kw != 0 {
+		m.AddKeyword(kw)
+	}
+	if ctx.combat != nil {
+		ctx.combat.emit(BuffEvent{
+			Target:  m.combatID,
+			Attack:  attack,
+			Health:  health,
+			Keyword: kw,
+			Owner:   ctx.combat.ownerOf(m),
+		})
+	}
+}
+
+// damage deals amount to m. Divine Shield absorbs the hit.
+func (ctx EffectContext) damage(m *Minion, amount int) {
+	if ctx.combat != nil {
+		ctx.combat.dealDamage(ctx.Source, m, amount, ctx.combat.ownerOf(m))
+		return
+	}
+	if m.HasKeyword(KeywordDivineShield) {
+		m.RemoveKeyword(KeywordDivineShield)
+		return
+	}
+	m.TakeDamage(amount)
+}
+
+// destroy kills m through Divine Shield. In combat the kill is replayed as
+// damage from the source, so the death that follows has a cause.
+func (ctx EffectContext) destroy(m *Minion) {
+	amount := m.Health()
+	m.TakeDamage(amount)
+	if ctx.combat != nil {
+		ctx.combat.emit(DamageEvent{
+			Source: ctx.Source.combatID,
+			Target: m.combatID,
+			Amount: amount,
+			Owner:  ctx.combat.ownerOf(m),
+		})
+	}
+}
+
+// removeDead removes minions killed by an effect outside of combat and returns
+// them to the pool. During combat the simulation handles deaths itself.
+func (ctx EffectContext) removeDead() {
+	if ctx.inCombat() || ctx.Board == nil {
+		return
+	}
+	for _, m := range ctx.Board.RemoveDead() {
+		if ctx.Pool != nil {
+			ctx.Pool.ReturnCard(m)
+		}
+	}
+}
+
 // TriggeredEffect pairs a trigger timing with an effect payload.
 type TriggeredEffect struct {
 	Trigger   Trigger
@@ -120,6 +203,17 @@ func MakeGoldenEffects(effects []TriggeredEffect) []TriggeredEffect {
 	return out
 }
 
+// targetedEffect is implemented by effects that resolve a Target.
+type targetedEffect interface {
+	target() Target
+}
+
+func (e *BuffStats) target() Target     { return e.Target }
+func (e *GiveKeyword) target() Target   { return e.Target }
+func (e *MakeGolden) target() Target    { return e.Target }
+func (e *DealDamage) target() Target    { return e.Target }
+func (e *DestroyMinion) target() Target { return e.Target }
+
 // BuffStats gives +Attack/+Health to targets.
 type BuffStats struct {
 	Target     Target
@@ -129,12 +223,18 @@ type BuffStats struct {
 }
 
 func (e *BuffStats) Apply(ctx EffectContext) error {
-	return errors.New("BuffStats.Apply not implemented")
+	for _, m := range e.Target.Resolve(ctx) {
+		ctx.buff(m, e.Attack, e.Health, 0)
+		if e.Persistent && ctx.combat != nil {
+			ctx.combat.persist(m, e.Attack, e.Health)
+		}
+	}
+	return nil
 }
 
 func (e *BuffStats) golden() Effect {
 	return &BuffStats{
-		Target:     Target{Type: e.Target.Type, Filter: e.Target.Filter, Count: e.Target.Count * 2},
+		Target:     e.Target.golden(),
 		Attack:     e.Attack * 2,
 		Health:     e.Health * 2,
 		Persistent: e.Persistent,
@@ -148,27 +248,49 @@ type GiveKeyword struct {
 }
 
 func (e *GiveKeyword) Apply(ctx EffectContext) error {
-	return errors.New("GiveKeyword.Apply not implemented")
+	for _, m := range e.Target.Resolve(ctx) {
+		ctx.buff(m, 0, 0, e.Keyword)
+	}
+	return nil
 }
 
 func (e *GiveKeyword) golden() Effect {
 	return &GiveKeyword{
-		Target:  Target{Type: e.Target.Type, Filter: e.Target.Filter, Count: e.Target.Count * 2},
+		Target:  e.Target.golden(),
 		Keyword: e.Keyword,
 	}
 }
 
 // SummonMinion spawns a minion on the board.
+// Nothing is summoned when the board is full.
 type SummonMinion struct {
 	TemplateID string
+	Golden     bool // summon a golden copy
 }
 
 func (e *SummonMinion) Apply(ctx EffectContext) error {
-	return errors.New("SummonMinion.Apply not implemented")
+	if ctx.Board == nil || ctx.Cards == nil {
+		return nil
+	}
+
+	tmpl := ctx.Cards.ByTemplateID(e.TemplateID)
+	if tmpl == nil {
+		return fmt.Errorf("unknown template %q", e.TemplateID)
+	}
+	if ctx.Board.IsFull() {
+		return nil
+	}
+
+	if e.Golden {
+		ctx.summon(NewGoldenMinion(tmpl))
+	} else {
+		ctx.summon(NewMinion(tmpl))
+	}
+	return nil
 }
 
 func (e *SummonMinion) golden() Effect {
-	return &SummonMinion{TemplateID: e.TemplateID}
+	return &SummonMinion{TemplateID: e.TemplateID, Golden: true}
 }
 
 // DiscoverCard lets the player discover cards from the pool.
@@ -189,11 +311,27 @@ func (e *DiscoverCard) Apply(ctx EffectContext) error {
 
 func (e *DiscoverCard) golden() Effect { return e }
 
-// MakeGolden makes the target minion golden.
-type MakeGolden struct{}
+// MakeGolden makes target minions golden: stats gain the template's base stats
+// again, effects and auras are upgraded and the triple reward is added to hand.
+type MakeGolden struct {
+	Target Target
+}
 
 func (e *MakeGolden) Apply(ctx EffectContext) error {
-	return errors.New("MakeGolden.Apply not implemented")
+	reward := &AddCard{TemplateID: TripleRewardID}
+	for _, m := range e.Target.Resolve(ctx) {
+		if m.IsGolden() {
+			continue
+		}
+		m.makeGolden()
+		if err := reward.Apply(ctx); err != nil {
+			return fmt.Errorf("triple reward: %w", err)
+		}
+	}
+	if ctx.Board != nil {
+		ctx.Board.RecomputeAuras()
+	}
+	return nil
 }
 
 func (e *MakeGolden) golden() Effect { return e }
@@ -205,7 +343,11 @@ type DealDamage struct {
 }
 
 func (e *DealDamage) Apply(ctx EffectContext) error {
-	return errors.New("DealDamage.Apply not implemented")
+	for _, m := range e.Target.Resolve(ctx) {
+		ctx.damage(m, e.Amount)
+	}
+	ctx.removeDead()
+	return nil
 }
 
 func (e *DealDamage) golden() Effect {
@@ -221,12 +363,16 @@ type DestroyMinion struct {
 }
 
 func (e *DestroyMinion) Apply(ctx EffectContext) error {
-	return errors.New("DestroyMinion.Apply not implemented")
+	for _, m := range e.Target.Resolve(ctx) {
+		ctx.destroy(m)
+	}
+	ctx.removeDead()
+	return nil
 }
 
 func (e *DestroyMinion) golden() Effect {
 	return &DestroyMinion{
-		Target: Target{Type: e.Target.Type, Filter: e.Target.Filter, Count: e.Target.Count * 2},
+		Target: e.Target.golden(),
 	}
 }
 
@@ -240,6 +386,10 @@ func (e *AddCard) Apply(ctx EffectContext) error {
 		return errors.New("empty template id")
 	}
 
+	if ctx.Hand == nil || ctx.Pool == nil {
+		return nil
+	}
+
 	tmpl := ctx.Pool.ByTemplateID(e.TemplateID)
 	if tmpl != nil && !ctx.Hand.IsFull() {
 		ctx.Hand.Add(NewCard(tmpl))
@@ -249,6 +399,21 @@ func (e *AddCard) Apply(ctx EffectContext) error {
 
 func (e *AddCard) golden() Effect { return e }
 
+// GainGold gives the player gold for the current turn.
+type GainGold struct {
+	Amount int
+}
+
+func (e *GainGold) Apply(ctx EffectContext) error {
+	if ctx.Gold == nil {
+		return nil
+	}
+	*ctx.Gold = min(*ctx.Gold+e.Amount, maxGold)
+	return nil
+}
+
+func (e *GainGold) golden() Effect { return &GainGold{Amount: e.Amount * 2} }
+
 // AuraScope defines the range of an aura effect.
 type AuraScope uint8
 
@@ -0,0 +1,33 @@
+package game
+
+// HeroTemplate is a read-only hero definition.
+type HeroTemplate interface {
+	ID() string
+	Name() string
+	HP() int    // starting health
+	Armor() int // starting armor
+	Power() HeroPower
+}
+
+// HeroCatalog provides the heroes offered at game start.
+type HeroCatalog interface {
+	Heroes() []HeroTemplate
+	ByHeroID(id string) HeroTemplate
+}
+
+// HeroPower is the hero's ability. Active powers are used once per turn for
+// Cost gold and fire TriggerHeroPower effects. Passive powers fire their
+// effects on turn triggers (Start of Turn, End of Turn) automatically.
+type HeroPower struct {
+	Name        string
+	Description string
+	Passive     bool
+	Cost        int // gold, active powers only
+	Effects     []TriggeredEffect
+}
+
+// NeedsTarget reports whether using the power asks the player to choose a friendly minion.
+func (hp HeroPower) NeedsTarget() bool {
+	_, ok := selectedTarget(hp.Effects, TriggerHeroPower)
+	return ok
+}
@@ -19,7 +19,14 @@ type Minion struct {
 	golden   bool
 	keywords Keywords
 	effects  []TriggeredEffect
+	auras    []Aura
 	combatID CombatID
+
+	// Bonuses from friendly auras, recomputed by the board.
+	auraAttack   int
+	auraHealth   int
+	auraKeywords Keywords
+	consumedAura Keywords // aura keywords spent in combat, not granted again
 }
 
 // NewMinion creates a minion from a card template.
@@ -31,9 +38,21 @@ func NewMinion(t CardTemplate) *Minion {
 		cost:     t.Cost(),
 		keywords: t.Keywords(),
 		effects:  t.Effects(),
+		auras:    t.Auras(),
 	}
 }
 
+// NewGoldenMinion creates a golden minion from a card template with doubled stats.
+func NewGoldenMinion(t CardTemplate) *Minion {
+	m := NewMinion(t)
+	m.attack *= 2
+	m.health *= 2
+	m.golden = true
+	m.effects = t.GoldenEffects()
+	m.auras = t.GoldenAuras()
+	return m
+}
+
 func (m *Minion) Template() CardTemplate { return m.template }
 func (m *Minion) TemplateID() string     { return m.template.ID() }
 func (m *Minion) Name() string           { return m.template.Name() }
@@ -42,12 +61,19 @@ func (m *Minion) Tribes() Tribes          { return m.template.Tribes() }
 func (m *Minion) Tier() Tier             { return m.template.Tier() }
 
 func (m *Minion) CombatID() CombatID { return m.combatID }
-func (m *Minion) Attack() int        { return m.attack }
-func (m *Minion) Health() int        { return m.health }
+func (m *Minion) Attack() int        { return m.attack + m.auraAttack }
+func (m *Minion) Health() int        { return m.health + m.auraHealth }
 func (m *Minion) Cost() int          { return m.cost }
 
-func (m *Minion) IsAlive() bool   { return m.health > 0 }
-func (m *Minion) CanAttack() bool { return m.health > 0 && m.attack > 0 }
+// AuraAttack and AuraHealth return the stats granted by friendly auras.
+func (m *Minion) AuraAttack() int { return m.auraAttack }
+func (m *Minion) AuraHealth() int { return m.auraHealth }
+
+// Auras returns the auras this minion provides while on the board.
+func (m *Minion) Auras() []Aura { return m.auras }
+
+func (m *Minion) IsAlive() bool   { return m.Health() > 0 }
+func (m *Minion) CanAttack() bool { return m.IsAlive() && m.Attack() > 0 }
 
 func (m *Minion) IsSpell() bool  { return false }
 func (m *Minion) IsMinion() bool { return true }
@@ -60,17 +86,45 @@ func (m *Minion) CanMagnetizeTo(target *Minion) bool {
 
 func (m *Minion) TakeDamage(amount int) { m.health -= amount }
 
-// Keywords returns the minion's current keywords bitmask.
-func (m *Minion) Keywords() Keywords { return m.keywords }
+// Buff adds attack and health to the minion.
+func (m *Minion) Buff(attack, health int) {
+	m.attack += attack
+	m.health += health
+}
 
-// HasKeyword returns true if the minion has the given static keyword.
-func (m *Minion) HasKeyword(kw Keyword) bool { return m.keywords.Has(kw) }
+// Keywords returns the minion's current keywords bitmask, including aura keywords.
+func (m *Minion) Keywords() Keywords {
+	kws := m.keywords
+	kws.Merge(m.auraKeywords)
+	return kws
+}
+
+// BaseKeywords returns the minion's own keywords, without aura keywords.
+func (m *Minion) BaseKeywords() Keywords { return m.keywords }
+
+// HasKeyword returns true if the minion has the given keyword.
+func (m *Minion) HasKeyword(kw Keyword) bool {
+	return m.keywords.Has(kw) || m.auraKeywords.Has(kw)
+}
 
 // AddKeyword adds a static keyword to the minion.
 func (m *Minion) AddKeyword(kw Keyword) { m.keywords.Add(kw) }
 
-// RemoveKeyword removes a static keyword from the minion.
-func (m *Minion) RemoveKeyword(kw Keyword) { m.keywords.Remove(kw) }
+// RemoveKeyword removes a keyword from the minion. A keyword granted by an
+// aura comes back on the next aura recompute.
+func (m *Minion) RemoveKeyword(kw Keyword) {
+	m.keywords.Remove(kw)
+	m.auraKeywords.Remove(kw)
+}
+
+// spendKeyword removes a keyword used up in combat, like a popped Divine
+// Shield. An aura granting the keyword doesn't grant it again.
+func (m *Minion) spendKeyword(kw Keyword) {
+	if m.auraKeywords.Has(kw) {
+		m.consumedAura.Add(kw)
+	}
+	m.RemoveKeyword(kw)
+}
 
 // AddEffect appends a triggered effect to the minion.
 func (m *Minion) AddEffect(te TriggeredEffect) { m.effects = append(m.effects, te) }
@@ -106,9 +160,19 @@ func (m *Minion) MergeGolden(other *Minion) *Minion {
 		golden:   true,
 		keywords: m.template.Keywords(),
 		effects:  effects,
+		auras:    m.template.GoldenAuras(),
 	}
 }
 
+// makeGolden turns the minion golden in place, keeping buffs.
+func (m *Minion) makeGolden() {
+	m.attack += m.template.Attack()
+	m.health += m.template.Health()
+	m.golden = true
+	m.effects = m.template.GoldenEffects()
+	m.auras = m.template.GoldenAuras()
+}
+
 func (m *Minion) Clone() *Minion {
 	if m == nil {
 		return nil
@@ -121,6 +185,38 @@ func (m *Minion) Clone() *Minion {
 		golden:   m.golden,
 		keywords: m.keywords,
 		effects:  slices.Clone(m.effects),
+		auras:    slices.Clone(m.auras),
 		combatID: m.combatID,
+
+		auraAttack:   m.auraAttack,
+		auraHealth:   m.auraHealth,
+		auraKeywords: m.auraKeywords,
+		consumedAura: m.consumedAura,
+	}
+}
+
+// resetAura clears aura bonuses.
+func (m *Minion) resetAura() {
+	m.auraAttack = 0
+	m.auraHealth = 0
+	m.auraKeywords = 0
+}
+
+// leaveBoard clears aura bonuses of a minion removed from the board.
+// A living minion keeps at least 1 health.
+func (m *Minion) leaveBoard() {
+	alive := m.IsAlive()
+	m.resetAura()
+	if alive && m.health <= 0 {
+		m.health = 1
+	}
+}
+
+// applyAura adds an aura's bonuses to the minion.
+func (m *Minion) applyAura(a Aura) {
+	m.auraAttack += a.Attack
+	m.auraHealth += a.Health
+	if a.Keyword != 0 && !m.consumedAura.Has(a.Keyword) {
+		m.auraKeywords.Add(a.Keyword)
 	}
 }
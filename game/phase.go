@@ -1,5 +1,7 @@
 package game
 
+import "time"
+
 type Phase uint8
 
 const (
@@ -7,6 +9,7 @@ const (
 	PhaseRecruit
 	PhaseCombat
 	PhaseFinished
+	PhaseHeroSelect
 )
 
 func (p Phase) String() string {
@@ -17,7 +20,39 @@ func (p Phase) String() string {
 		return "Combat"
 	case PhaseFinished:
 		return "Finished"
+	case PhaseHeroSelect:
+		return "Hero Select"
 	default:
 		return "Unknown"
 	}
 }
+
+// RecruitDuration returns the recruit phase length of the turn. Later turns
+// get more time as boards and shops grow.
+func RecruitDuration(turn int) time.Duration {
+	d := recruitBaseDuration + time.Duration(max(turn-1, 0))*recruitTurnDuration
+	return min(d, MaxRecruitDuration)
+}
+
+// CombatDuration returns the combat phase length needed to animate the
+// longest of the combat logs, capped so a stalled fight can't hold up the
+// lobby. Longer logs are played faster, see CombatPlaybackSpeed.
+func CombatDuration(logs []CombatLog) time.Duration {
+	var events int
+	for _, log := range logs {
+		events = max(events, len(log.Events))
+	}
+	return min(combatAnimDuration(events), maxCombatDuration)
+}
+
+// CombatPlaybackSpeed returns how many times faster than normal a combat log
+// of the given length is played, so it finishes within CombatDuration.
+func CombatPlaybackSpeed(events int) float64 {
+	return max(1, float64(combatAnimDuration(events))/float64(maxCombatDuration))
+}
+
+// combatAnimDuration returns how long a combat log takes to animate at
+// normal speed.
+func combatAnimDuration(events int) time.Duration {
+	return combatBaseDuration + time.Duration(events)*CombatEventDuration
+}
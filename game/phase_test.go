@@ -0,0 +1,49 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecruitDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		turn int
+		want time.Duration
+	}{
+		{name: "first turn", turn: 1, want: 20 * time.Second},
+		{name: "later turn", turn: 3, want: 30 * time.Second},
+		{name: "capped", turn: 20, want: 60 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, RecruitDuration(tt.turn))
+		})
+	}
+}
+
+func TestCombatDuration(t *testing.T) {
+	t.Parallel()
+
+	logs := []CombatLog{
+		{Events: make([]CombatEvent, 4)},
+		{Events: make([]CombatEvent, 10)},
+		{},
+	}
+	assert.Equal(t, combatBaseDuration+10*CombatEventDuration, CombatDuration(logs))
+	assert.Equal(t, combatBaseDuration, CombatDuration(nil))
+
+	// A long fight isn't cut off, it plays faster to fit the capped phase.
+	n := maxCombatIterations * 3
+	endless := []CombatLog{{Events: make([]CombatEvent, n)}}
+	assert.Equal(t, maxCombatDuration, CombatDuration(endless))
+	played := float64(combatBaseDuration+time.Duration(n)*CombatEventDuration) / CombatPlaybackSpeed(n)
+	assert.InDelta(t, float64(maxCombatDuration), played, float64(time.Millisecond))
+	assert.Equal(t, 1.0, CombatPlaybackSpeed(10), "short fights play at normal speed")
+}
@@ -33,11 +33,16 @@ const (
 	ErrNotASpell            errors.Error = "card is not a spell"
 	ErrDiscoverPending      errors.Error = "discover already pending"
 	ErrNoDiscover           errors.Error = "no discover options"
+	ErrInvalidTarget        errors.Error = "invalid target"
+	ErrNoHero               errors.Error = "no hero selected"
+	ErrHeroPowerPassive     errors.Error = "hero power is passive"
+	ErrHeroPowerUsed        errors.Error = "hero power already used this turn"
 )
 
 type Player struct {
 	id        PlayerID
 	hp        int
+	armor     int // absorbs damage before hp
 	gold      int
 	maxGold   int
 	placement int
@@ -45,6 +50,9 @@ type Player struct {
 	board     Board  // minions on board
 	hand      Hand   // can hold minions and spells
 	discovers []Card // pending discover options
+
+	hero          HeroTemplate // nil until picked
+	heroPowerUsed bool         // active power used this turn
 }
 
 func NewPlayer(id PlayerID) *Player {
@@ -59,14 +67,42 @@ func NewPlayer(id PlayerID) *Player {
 	}
 }
 
+// NewGhost creates a stand-in player that fights with a copy of board at the
+// given shop tier, such as an eliminated player's last combat board.
+func NewGhost(id PlayerID, board Board, tier Tier) *Player {
+	p := NewPlayer(id)
+	p.board = board.Clone()
+	if tier.IsValid() {
+		p.shop.tier = tier
+	}
+	return p
+}
+
 func (p *Player) ID() PlayerID       { return p.id }
 func (p *Player) HP() int            { return p.hp }
+func (p *Player) Armor() int         { return p.armor }
 func (p *Player) Gold() int          { return p.gold }
 func (p *Player) MaxGold() int       { return p.maxGold }
 func (p *Player) Placement() int     { return p.placement }
 func (p *Player) SetPlacement(n int) { p.placement = n }
 func (p *Player) Shop() Shop         { return p.shop }
 
+// Hero returns the player's hero, or nil if none was picked yet.
+func (p *Player) Hero() HeroTemplate { return p.hero }
+
+// HeroPowerUsed reports whether the active hero power was used this turn.
+func (p *Player) HeroPowerUsed() bool { return p.heroPowerUsed }
+
+// SetHero assigns the hero and resets health and armor to the hero's starting values.
+func (p *Player) SetHero(h HeroTemplate) {
+	p.hero = h
+	p.hp = h.HP()
+	p.armor = h.Armor()
+}
+
+// Health returns hp plus armor, the total damage the player can still take.
+func (p *Player) Health() int { return p.hp + p.armor }
+
 // Hand returns a copy of the player's hand cards.
 func (p *Player) Hand() []Card { return p.hand.Cards() }
 
@@ -88,18 +124,106 @@ func (p *Player) BoardSize() int { return p.board.Len() }
 // IsAlive returns true if the player has HP remaining.
 func (p *Player) IsAlive() bool { return p.hp > 0 }
 
-// StartTurn prepares the player for a new turn.
-func (p *Player) StartTurn(pool *CardPool, turn int) {
+// StartTurn prepares the player for a new turn and fires Start of Turn
+// effects after the shop is rolled. A passive hero power fires before minions.
+func (p *Player) StartTurn(pool *CardPool, turn int) error {
 	if turn > 1 && p.maxGold < maxGold {
 		p.maxGold++
 	}
 	p.gold = p.maxGold
+	p.heroPowerUsed = false
 	p.shop.StartTurn(pool)
+	if err := p.applyHeroEffects(TriggerStartOfTurn, pool); err != nil {
+		return err
+	}
+	return p.applyBoardEffects(TriggerStartOfTurn, pool)
+}
+
+// EndTurn fires End of Turn effects. Called before the combat snapshot.
+func (p *Player) EndTurn(pool *CardPool) error {
+	if err := p.applyHeroEffects(TriggerEndOfTurn, pool); err != nil {
+		return err
+	}
+	return p.applyBoardEffects(TriggerEndOfTurn, pool)
+}
+
+// applyHeroEffects runs passive hero power effects of the given trigger.
+func (p *Player) applyHeroEffects(t Trigger, pool *CardPool) error {
+	if p.hero == nil || !p.hero.Power().Passive {
+		return nil
+	}
+	ctx := newEffectContext(p, pool)
+	for e := range EffectsByTrigger(p.hero.Power().Effects, t) {
+		if err := e.Apply(ctx); err != nil {
+			return fmt.Errorf("%s %T: %w", p.hero.ID(), e, err)
+		}
+	}
+	return nil
 }
 
-// TakeDamage reduces player HP and returns true if player is dead.
+// UseHeroPower activates the hero's active power for its gold cost, once per
+// turn. Powers aimed at a friendly minion use the board minion at targetIdx;
+// targetIdx is ignored by other powers.
+func (p *Player) UseHeroPower(targetIdx int, pool *CardPool) error {
+	if p.hero == nil {
+		return ErrNoHero
+	}
+
+	power := p.hero.Power()
+	if power.Passive {
+		return ErrHeroPowerPassive
+	}
+	if p.heroPowerUsed {
+		return ErrHeroPowerUsed
+	}
+	if p.gold < power.Cost {
+		return ErrNotEnoughGold
+	}
+
+	ctx := newEffectContext(p, pool)
+
+	if t, ok := selectedTarget(power.Effects, TriggerHeroPower); ok {
+		target := p.board.MinionAt(targetIdx)
+		if !t.Filter.Matches(target, nil) {
+			return ErrInvalidTarget
+		}
+		ctx.Selected = target
+	}
+
+	p.gold -= power.Cost
+	p.heroPowerUsed = true
+
+	for e := range EffectsByTrigger(power.Effects, TriggerHeroPower) {
+		if err := e.Apply(ctx); err != nil {
+			return fmt.Errorf("%T: %w", e, err)
+		}
+	}
+
+	return nil
+}
+
+// applyBoardEffects runs effects of the given trigger for board minions,
+// left to right. Minions removed by earlier effects are skipped.
+func (p *Player) applyBoardEffects(t Trigger, pool *CardPool) error {
+	for _, m := range p.board.Minions() {
+		if p.board.IndexOf(m) < 0 {
+			continue
+		}
+		ctx := newEffectContext(p, pool).WithSource(m)
+		for e := range m.EffectsByTrigger(t) {
+			if err := e.Apply(ctx); err != nil {
+				return fmt.Errorf("%s %T: %w", m.TemplateID(), e, err)
+			}
+		}
+	}
+	return nil
+}
+
+// TakeDamage reduces armor first, then HP, and returns true if player is dead.
 func (p *Player) TakeDamage(damage int) bool {
-	p.hp -= damage
+	absorbed := min(p.armor, damage)
+	p.armor -= absorbed
+	p.hp -= damage - absorbed
 	return p.hp <= 0
 }
 
@@ -160,19 +284,28 @@ func (p *Player) CanPlayCard(handIdx int) error {
 	return nil
 }
 
+// newEffectContext returns the context for effects of the player. Without a
+// pool, effects that need cards from it do nothing.
 func newEffectContext(p *Player, pool *CardPool) EffectContext {
-	return EffectContext{
+	ctx := EffectContext{
 		Board:     &p.board,
 		Hand:      &p.hand,
 		Shop:      &p.shop,
 		Pool:      pool,
 		Discovers: &p.discovers,
+		Gold:      &p.gold,
 	}
+	if pool != nil {
+		ctx.Cards = pool.cards
+	}
+	return ctx
 }
 
 // PlayMinion moves a minion from hand to board and executes its Battlecry effects.
 // If the minion has Magnetic and is placed to the left of a Mech, it fuses onto that Mech instead.
-func (p *Player) PlayMinion(handIdx, boardIdx int, pool *CardPool) error {
+// A targeted Battlecry is aimed at the minion at targetIdx on the board before placement;
+// it does nothing when targetIdx is negative or no friendly minion is a valid target.
+func (p *Player) PlayMinion(handIdx, boardIdx, targetIdx int, pool *CardPool) error {
 	if !p.hand.HasCardAt(handIdx) {
 		return ErrInvalidHandIndex
 	}
@@ -182,6 +315,11 @@ func (p *Player) PlayMinion(handIdx, boardIdx int, pool *CardPool) error {
 		return ErrNotAMinion
 	}
 
+	selected, err := p.battlecryTarget(minion, targetIdx)
+	if err != nil {
+		return err
+	}
+
 	target := p.board.MinionAt(boardIdx)
 	magnetize := minion.CanMagnetizeTo(target)
 
@@ -198,11 +336,13 @@ func (p *Player) PlayMinion(handIdx, boardIdx int, pool *CardPool) error {
 
 	if magnetize {
 		magnitizeMinions(minion, target)
+		p.board.RecomputeAuras()
 	} else {
 		p.board.PlaceMinion(minion, boardIdx)
 	}
 
 	ctx := newEffectContext(p, pool).WithSource(minion)
+	ctx.Selected = selected
 	for e := range minion.EffectsByTrigger(TriggerBattlecry, TriggerGolden) {
 		if err := e.Apply(ctx); err != nil {
 			return fmt.Errorf("%T: %w", e, err)
@@ -212,13 +352,33 @@ func (p *Player) PlayMinion(handIdx, boardIdx int, pool *CardPool) error {
 	return nil
 }
 
-// magnitizeMinions fuses source stats, keywords and effects onto target.
+// battlecryTarget returns the board minion at targetIdx chosen for a targeted
+// Battlecry. Returns nil without error if the Battlecry isn't targeted or no
+// board minion is a valid target.
+func (p *Player) battlecryTarget(minion *Minion, targetIdx int) (*Minion, error) {
+	t, ok := selectedTarget(minion.effects, TriggerBattlecry)
+	if !ok || targetIdx < 0 {
+		return nil, nil
+	}
+	if target := p.board.MinionAt(targetIdx); t.Filter.Matches(target, minion) {
+		return target, nil
+	}
+	for _, m := range p.board.minions {
+		if t.Filter.Matches(m, minion) {
+			return nil, ErrInvalidTarget
+		}
+	}
+	return nil, nil
+}
+
+// magnitizeMinions fuses source stats, keywords, effects and auras onto target.
 func magnitizeMinions(source, target *Minion) {
 	target.attack += source.attack
 	target.health += source.health
 	target.keywords.Merge(source.keywords)
 	target.keywords.Remove(KeywordMagnetic)
 	target.effects = append(target.effects, source.effects...)
+	target.auras = append(target.auras, source.auras...)
 }
 
 // RemoveMinion moves a minion from board to hand.
@@ -328,8 +488,10 @@ func (p *Player) CheckTriples() bool {
 	return false
 }
 
-// PlaySpell plays a spell from hand.
-func (p *Player) PlaySpell(handIdx int, pool *CardPool) error {
+// PlaySpell plays a spell from hand. Spells aimed at a friendly minion cast on
+// the board minion at targetIdx, which must match the spell's target filter;
+// targetIdx is ignored by other spells.
+func (p *Player) PlaySpell(handIdx, targetIdx int, pool *CardPool) error {
 	if err := p.CanPlayCard(handIdx); err != nil {
 		return err
 	}
@@ -339,10 +501,21 @@ func (p *Player) PlaySpell(handIdx int, pool *CardPool) error {
 		return ErrNotASpell
 	}
 
+	effects := spell.Template().Effects()
+	ctx := newEffectContext(p, pool)
+
+	if t, ok := selectedTarget(effects, TriggerSpell); ok {
+		target := p.board.MinionAt(targetIdx)
+		if !t.Filter.Matches(target, nil) || (target.IsGolden() && makesGolden(effects)) {
+			return ErrInvalidTarget
+		}
+		ctx.Selected = target
+	}
+
 	p.hand.RemoveCard(handIdx)
 
-	for e := range EffectsByTrigger(spell.Template().Effects(), TriggerSpell) {
-		if err := e.Apply(newEffectContext(p, pool)); err != nil {
+	for e := range EffectsByTrigger(effects, TriggerSpell) {
+		if err := e.Apply(ctx); err != nil {
 			return fmt.Errorf("%T: %w", e, err)
 		}
 	}
@@ -350,6 +523,16 @@ func (p *Player) PlaySpell(handIdx int, pool *CardPool) error {
 	return nil
 }
 
+// makesGolden reports whether the spell effects include MakeGolden.
+func makesGolden(effects []TriggeredEffect) bool {
+	for e := range EffectsByTrigger(effects, TriggerSpell) {
+		if _, ok := e.(*MakeGolden); ok {
+			return true
+		}
+	}
+	return false
+}
+
 // DiscoverPick picks one of the discover options and adds it to hand.
 // Unpicked options are returned to the pool.
 func (p *Player) DiscoverPick(index int, pool *CardPool) error {
@@ -0,0 +1,305 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlayer_TurnEffects(t *testing.T) {
+	t.Parallel()
+
+	coin := &testTemplate{id: "coin", tier: Tier1}
+	pool := NewCardPool(testCatalog{"coin": coin}, 2)
+
+	m := NewMinion(&testTemplate{
+		id: "trader", tier: Tier2, attack: 1, health: 1,
+		effects: []TriggeredEffect{
+			{Trigger: TriggerStartOfTurn, Effect: &GainGold{Amount: 2}},
+			{Trigger: TriggerStartOfTurn, Effect: &AddCard{TemplateID: "coin"}},
+			{Trigger: TriggerEndOfTurn, Effect: &BuffStats{Target: Target{Type: TargetSelf}, Attack: 1, Health: 2}},
+		},
+	})
+	p := NewPlayer(1)
+	p.maxGold = 3
+	p.board.PlaceMinion(m, 0)
+
+	require.NoError(t, p.StartTurn(pool, 1))
+	assert.Equal(t, 5, p.Gold())
+	assert.Equal(t, 1, p.HandSize())
+	assert.Equal(t, 1, m.Attack(), "end of turn doesn't fire at start of turn")
+
+	require.NoError(t, p.EndTurn(pool))
+	assert.Equal(t, 2, m.Attack())
+	assert.Equal(t, 3, m.Health())
+	assert.Equal(t, 1, p.HandSize())
+}
+
+func TestPlayer_PlaySpell_Target(t *testing.T) {
+	t.Parallel()
+
+	bolster := &testTemplate{id: "bolster", kind: CardKindSpell, effects: []TriggeredEffect{
+		{Trigger: TriggerSpell, Effect: &GiveKeyword{
+			Target:  Target{Type: TargetFriendlySelected, Filter: TargetFilter{Tribe: TribeBeast}},
+			Keyword: KeywordTaunt,
+		}},
+	}}
+	touch := &testTemplate{id: "touch", kind: CardKindSpell, effects: []TriggeredEffect{
+		{Trigger: TriggerSpell, Effect: &MakeGolden{Target: Target{Type: TargetFriendlySelected}}},
+	}}
+	reward := &testTemplate{id: TripleRewardID, kind: CardKindSpell}
+	pool := NewCardPool(testCatalog{TripleRewardID: reward}, 2)
+
+	tests := []struct {
+		name    string
+		spell   CardTemplate
+		target  int
+		wantErr error
+	}{
+		{name: "valid target", spell: bolster, target: 1},
+		{name: "filtered out", spell: bolster, target: 0, wantErr: ErrInvalidTarget},
+		{name: "out of range", spell: bolster, target: 5, wantErr: ErrInvalidTarget},
+		{name: "no target", spell: bolster, target: -1, wantErr: ErrInvalidTarget},
+		{name: "already golden", spell: touch, target: 2, wantErr: ErrInvalidTarget},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := NewPlayer(1)
+			p.board = *testBoard(t,
+				testMinion(t, "murloc", NewTribes(TribeMurloc), Tier1),
+				testMinion(t, "beast", NewTribes(TribeBeast), Tier1),
+				NewGoldenMinion(&testTemplate{id: "golden", tier: Tier1, attack: 1, health: 1}),
+			)
+			p.hand.Add(NewCard(tt.spell))
+
+			err := p.PlaySpell(0, tt.target, pool)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, 1, p.HandSize(), "spell stays in hand")
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, p.board.MinionAt(tt.target).HasKeyword(KeywordTaunt))
+			assert.Zero(t, p.HandSize())
+		})
+	}
+}
+
+func TestMakeGolden_Apply(t *testing.T) {
+	t.Parallel()
+
+	reward := &testTemplate{id: TripleRewardID, kind: CardKindSpell}
+	pool := NewCardPool(testCatalog{TripleRewardID: reward}, 2)
+
+	m := NewMinion(&testTemplate{
+		id: "pup", tier: Tier1, attack: 2, health: 3,
+		effects: []TriggeredEffect{
+			{Trigger: TriggerDeathrattle, Effect: &BuffStats{Target: Target{Type: TargetAllFriendly}, Attack: 1}},
+		},
+	})
+	m.Buff(1, 1)
+
+	p := NewPlayer(1)
+	p.board.PlaceMinion(m, 0)
+
+	ctx := newEffectContext(p, pool)
+	ctx.Selected = m
+	require.NoError(t, (&MakeGolden{Target: Target{Type: TargetFriendlySelected}}).Apply(ctx))
+
+	assert.True(t, m.IsGolden())
+	assert.Equal(t, 5, m.Attack(), "keeps buffs and adds base stats")
+	assert.Equal(t, 7, m.Health())
+	bs, ok := m.effects[0].Effect.(*BuffStats)
+	require.True(t, ok)
+	assert.Equal(t, 2, bs.Attack)
+	require.Equal(t, 1, p.HandSize())
+	assert.Equal(t, TripleRewardID, p.Hand()[0].Template().ID())
+}
+
+func TestPlayer_PlayMinion_BattlecryTarget(t *testing.T) {
+	t.Parallel()
+
+	trainer := &testTemplate{id: "trainer", tier: Tier2, attack: 2, health: 2, effects: []TriggeredEffect{
+		{Trigger: TriggerBattlecry, Effect: &BuffStats{
+			Target: Target{Type: TargetFriendlySelected, Filter: TargetFilter{Tribe: TribeBeast}},
+			Attack: 2,
+			Health: 2,
+		}},
+	}}
+	assert.True(t, NeedsTarget(trainer))
+	assert.False(t, NeedsTarget(&testTemplate{id: "plain"}))
+	pool := NewCardPool(testCatalog{}, 2)
+
+	tests := []struct {
+		name       string
+		board      []Tribes
+		target     int
+		wantErr    error
+		wantBuffed int // board index (before placement) expected to be buffed, -1 none
+	}{
+		{name: "valid target", board: []Tribes{NewTribes(TribeMurloc), NewTribes(TribeBeast)}, target: 1, wantBuffed: 1},
+		{name: "invalid with valid available", board: []Tribes{NewTribes(TribeMurloc), NewTribes(TribeBeast)}, target: 0, wantErr: ErrInvalidTarget},
+		{name: "no target with valid available is no-op", board: []Tribes{NewTribes(TribeMurloc), NewTribes(TribeBeast)}, target: -1, wantBuffed: -1},
+		{name: "no valid target is no-op", board: []Tribes{NewTribes(TribeMurloc)}, target: -1, wantBuffed: -1},
+		{name: "empty board", target: -1, wantBuffed: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := NewPlayer(1)
+			var minions []*Minion
+			for i, tribes := range tt.board {
+				m := testMinion(t, "m", tribes, Tier1)
+				p.board.PlaceMinion(m, i)
+				minions = append(minions, m)
+			}
+			p.hand.Add(NewMinion(trainer))
+
+			err := p.PlayMinion(0, len(tt.board), tt.target, pool)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, 1, p.HandSize())
+				return
+			}
+			require.NoError(t, err)
+			for i, m := range minions {
+				want := 1
+				if i == tt.wantBuffed {
+					want = 3
+				}
+				assert.Equal(t, want, m.Attack())
+			}
+		})
+	}
+}
+
+type testHero struct {
+	id    string
+	hp    int
+	armor int
+	power HeroPower
+}
+
+func (h *testHero) ID() string       { return h.id }
+func (h *testHero) Name() string     { return h.id }
+func (h *testHero) HP() int          { return h.hp }
+func (h *testHero) Armor() int       { return h.armor }
+func (h *testHero) Power() HeroPower { return h.power }
+
+func TestPlayer_UseHeroPower(t *testing.T) {
+	t.Parallel()
+
+	pool := NewCardPool(testCatalog{}, 2)
+	shield := &testHero{id: "george", hp: 40, power: HeroPower{
+		Name: "Boon",
+		Cost: 2,
+		Effects: []TriggeredEffect{{Trigger: TriggerHeroPower, Effect: &GiveKeyword{
+			Target:  Target{Type: TargetFriendlySelected},
+			Keyword: KeywordDivineShield,
+		}}},
+	}}
+	assert.True(t, shield.Power().NeedsTarget())
+
+	p := NewPlayer(1)
+	assert.ErrorIs(t, p.UseHeroPower(-1, pool), ErrNoHero)
+
+	p.SetHero(shield)
+	assert.Equal(t, 40, p.HP())
+
+	m := NewMinion(&testTemplate{id: "pup", tier: Tier1, attack: 1, health: 1})
+	p.board.PlaceMinion(m, 0)
+	gold := p.Gold()
+
+	assert.ErrorIs(t, p.UseHeroPower(1, pool), ErrInvalidTarget)
+	assert.False(t, p.HeroPowerUsed(), "invalid target doesn't use the power")
+
+	require.NoError(t, p.UseHeroPower(0, pool))
+	assert.True(t, m.HasKeyword(KeywordDivineShield))
+	assert.Equal(t, gold-2, p.Gold())
+	assert.ErrorIs(t, p.UseHeroPower(0, pool), ErrHeroPowerUsed)
+
+	require.NoError(t, p.StartTurn(pool, 2))
+	assert.False(t, p.HeroPowerUsed(), "resets at start of turn")
+
+	p.gold = 1
+	assert.ErrorIs(t, p.UseHeroPower(0, pool), ErrNotEnoughGold)
+}
+
+func TestPlayer_HeroPassive(t *testing.T) {
+	t.Parallel()
+
+	pool := NewCardPool(testCatalog{}, 2)
+	p := NewPlayer(1)
+	p.maxGold = 3
+	p.SetHero(&testHero{id: "gallywix", hp: 30, power: HeroPower{
+		Name:    "Savings",
+		Passive: true,
+		Effects: []TriggeredEffect{{Trigger: TriggerStartOfTurn, Effect: &GainGold{Amount: 1}}},
+	}})
+
+	assert.ErrorIs(t, p.UseHeroPower(-1, pool), ErrHeroPowerPassive)
+	require.NoError(t, p.StartTurn(pool, 1))
+	assert.Equal(t, 4, p.Gold())
+}
+
+func TestPlayer_NilPool(t *testing.T) {
+	t.Parallel()
+
+	p := NewPlayer(1)
+	p.gold = 10
+	p.SetHero(&testHero{id: "gold", hp: 30, power: HeroPower{
+		Name:    "Gold",
+		Cost:    1,
+		Effects: []TriggeredEffect{{Trigger: TriggerHeroPower, Effect: &GainGold{Amount: 2}}},
+	}})
+	p.board.PlaceMinion(NewMinion(&testTemplate{
+		id: "summoner", tier: Tier1, attack: 1, health: 1,
+		effects: []TriggeredEffect{
+			{Trigger: TriggerEndOfTurn, Effect: &SummonMinion{TemplateID: "token"}},
+			{Trigger: TriggerEndOfTurn, Effect: &AddCard{TemplateID: "token"}},
+		},
+	}), 0)
+
+	require.NoError(t, p.UseHeroPower(-1, nil))
+	assert.Equal(t, 11, p.Gold())
+	require.NoError(t, p.EndTurn(nil))
+	assert.Equal(t, 1, p.BoardSize(), "nothing to summon from without a pool")
+	assert.Zero(t, p.HandSize())
+}
+
+func TestPlayer_TakeDamage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		armor     int
+		damage    int
+		wantHP    int
+		wantArmor int
+		wantDead  bool
+	}{
+		{name: "armor absorbs all", armor: 5, damage: 3, wantHP: 10, wantArmor: 2},
+		{name: "armor absorbs part", armor: 2, damage: 5, wantHP: 7},
+		{name: "no armor", damage: 4, wantHP: 6},
+		{name: "lethal through armor", armor: 3, damage: 14, wantHP: -1, wantDead: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p := NewPlayer(1)
+			p.SetHero(&testHero{id: "hero", hp: 10, armor: tt.armor, power: HeroPower{Name: "Power", Passive: true}})
+
+			assert.Equal(t, tt.wantDead, p.TakeDamage(tt.damage))
+			assert.Equal(t, tt.wantHP, p.HP())
+			assert.Equal(t, tt.wantArmor, p.Armor())
+			assert.Equal(t, tt.wantHP+tt.wantArmor, p.Health())
+		})
+	}
+}
@@ -18,3 +18,29 @@ type GameResult struct {
 	StartedAt  time.Time
 	EndedAt    time.Time
 }
+
+// DamageCap limits combat damage through a turn.
+type DamageCap struct {
+	UntilTurn int // last turn the cap applies to
+	Max       int
+}
+
+// DamageCaps is a list of damage caps ordered by UntilTurn.
+type DamageCaps []DamageCap
+
+// DefaultDamageCaps caps damage at 5 through turn 3 and 10 through turn 7.
+// Later turns are uncapped.
+var DefaultDamageCaps = DamageCaps{
+	{UntilTurn: 3, Max: 5},
+	{UntilTurn: 7, Max: 10},
+}
+
+// Apply returns damage limited by the first cap covering the turn.
+func (c DamageCaps) Apply(turn, damage int) int {
+	for _, dc := range c {
+		if turn <= dc.UntilTurn {
+			return min(damage, dc.Max)
+		}
+	}
+	return damage
+}
@@ -0,0 +1,32 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDamageCaps_Apply(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		caps   DamageCaps
+		turn   int
+		damage int
+		want   int
+	}{
+		{name: "first cap", caps: DefaultDamageCaps, turn: 3, damage: 9, want: 5},
+		{name: "second cap", caps: DefaultDamageCaps, turn: 4, damage: 12, want: 10},
+		{name: "below cap", caps: DefaultDamageCaps, turn: 1, damage: 2, want: 2},
+		{name: "uncapped late", caps: DefaultDamageCaps, turn: 8, damage: 25, want: 25},
+		{name: "no caps", turn: 1, damage: 25, want: 25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.caps.Apply(tt.turn, tt.damage))
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package game
+
+import (
+	"runtime"
+	"sync"
+)
+
+// SimSide is one side of a simulated combat.
+type SimSide struct {
+	Player PlayerID
+	Board  Board
+	Tier   Tier // shop tier, adds to damage on a win
+}
+
+// SimResult aggregates simulated combats from the first side's perspective.
+type SimResult struct {
+	Runs        int
+	Wins        int
+	Ties        int
+	Losses      int
+	DamageDealt map[int]int // damage dealt on a win → number of runs
+	DamageTaken map[int]int // damage taken on a loss → number of runs
+}
+
+// WinRate returns the share of runs won, from 0 to 1.
+func (r SimResult) WinRate() float64 { return r.rate(r.Wins) }
+
+// TieRate returns the share of runs tied, from 0 to 1.
+func (r SimResult) TieRate() float64 { return r.rate(r.Ties) }
+
+// LossRate returns the share of runs lost, from 0 to 1.
+func (r SimResult) LossRate() float64 { return r.rate(r.Losses) }
+
+func (r SimResult) rate(n int) float64 {
+	if r.Runs == 0 {
+		return 0
+	}
+	return float64(n) / float64(r.Runs)
+}
+
+func (r *SimResult) add(res CombatResult, p1 PlayerID) {
+	r.Runs++
+	switch res.Winner {
+	case 0:
+		r.Ties++
+	case p1:
+		r.Wins++
+		r.DamageDealt[res.Damage]++
+	default:
+		r.Losses++
+		r.DamageTaken[res.Damage]++
+	}
+}
+
+func (r *SimResult) merge(other SimResult) {
+	r.Runs += other.Runs
+	r.Wins += other.Wins
+	r.Ties += other.Ties
+	r.Losses += other.Losses
+	for k, v := range other.DamageDealt {
+		r.DamageDealt[k] += v
+	}
+	for k, v := range other.DamageTaken {
+		r.DamageTaken[k] += v
+	}
+}
+
+func newSimResult() SimResult {
+	return SimResult{
+		DamageDealt: make(map[int]int),
+		DamageTaken: make(map[int]int),
+	}
+}
+
+// Simulate runs the combat between two boards runs times in parallel with the
+// real combat engine. Run i uses seed+i, so the result doesn't depend on scheduling.
+// Input boards are never modified. Missing or equal player IDs are replaced.
+func Simulate(a, b SimSide, cards CardCatalog, runs int, seed uint64) SimResult {
+	if a.Player == 0 {
+		a.Player = 1
+	}
+	if b.Player == 0 || b.Player == a.Player {
+		b.Player = a.Player + 1
+	}
+
+	workers := min(runtime.GOMAXPROCS(0), max(runs, 1))
+	results := make([]SimResult, workers)
+
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Go(func() {
+			res := newSimResult()
+			for i := w; i < runs; i += workers {
+				c := NewCombat(a.player(), b.player(), cards, seed+uint64(i))
+				r1, _ := c.Run()
+				res.add(r1, a.Player)
+			}
+			results[w] = res
+		})
+	}
+	wg.Wait()
+
+	total := newSimResult()
+	for _, r := range results {
+		total.merge(r)
+	}
+	return total
+}
+
+// player returns a fresh player holding a copy of the side's board.
+func (s SimSide) player() *Player {
+	return NewGhost(s.Player, s.Board, s.Tier)
+}
@@ -0,0 +1,68 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulate(t *testing.T) {
+	t.Parallel()
+
+	minion := func(attack, health int) *Minion {
+		return NewMinion(&testTemplate{id: "m", tier: Tier2, attack: attack, health: health})
+	}
+
+	tests := []struct {
+		name       string
+		a, b       *Board
+		wantWins   int
+		wantTies   int
+		wantLosses int
+		wantDealt  map[int]int
+	}{
+		{
+			name:      "stronger board always wins",
+			a:         testBoard(t, minion(10, 10)),
+			b:         testBoard(t, minion(1, 1)),
+			wantWins:  100,
+			wantDealt: map[int]int{1 + 2: 100}, // tier 1 shop + tier 2 minion
+		},
+		{
+			name:     "mirror trades into a tie",
+			a:        testBoard(t, minion(2, 2)),
+			b:        testBoard(t, minion(2, 2)),
+			wantTies: 100,
+		},
+		{
+			name:       "empty board loses",
+			a:          testBoard(t),
+			b:          testBoard(t, minion(1, 1)),
+			wantLosses: 100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			res := Simulate(SimSide{Board: *tt.a}, SimSide{Board: *tt.b}, nil, 100, 1)
+			assert.Equal(t, 100, res.Runs)
+			assert.Equal(t, tt.wantWins, res.Wins)
+			assert.Equal(t, tt.wantTies, res.Ties)
+			assert.Equal(t, tt.wantLosses, res.Losses)
+			if tt.wantDealt != nil {
+				assert.Equal(t, tt.wantDealt, res.DamageDealt)
+			}
+		})
+	}
+
+	t.Run("input board untouched", func(t *testing.T) {
+		t.Parallel()
+
+		m := minion(3, 3)
+		b := testBoard(t, m)
+		Simulate(SimSide{Board: *b}, SimSide{Board: *testBoard(t, minion(1, 1))}, nil, 10, 1)
+		assert.Equal(t, 3, m.Health())
+	})
+}
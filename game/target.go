@@ -0,0 +1,175 @@
+package game
+
+import "math/rand/v2"
+
+// Matches reports whether m passes the filter. Source is used for ExcludeSource.
+func (f TargetFilter) Matches(m, source *Minion) bool {
+	if m == nil {
+		return false
+	}
+	if f.ExcludeSource && m == source {
+		return false
+	}
+	if f.Tribe != TribeNeutral && !m.Tribes().Has(f.Tribe) {
+		return false
+	}
+	if f.Tier != 0 && m.Tier() != f.Tier {
+		return false
+	}
+	if f.HasKeyword != 0 && !m.HasKeyword(f.HasKeyword) {
+		return false
+	}
+	return true
+}
+
+// isRandom reports whether the target type picks minions at random.
+func (t TargetType) isRandom() bool {
+	return t == TargetRandomFriendly || t == TargetRandomEnemy
+}
+
+// golden returns a copy with Count doubled. Random targets with Count 0
+// pick a single minion, so they double to 2.
+func (t Target) golden() Target {
+	count := t.Count
+	if t.Type.isRandom() {
+		count = max(count, 1)
+	}
+	return Target{Type: t.Type, Filter: t.Filter, Count: count * 2}
+}
+
+// Resolve returns the living minions the target refers to in the given context.
+// Returns nil if nothing matches or the required board is missing.
+func (t Target) Resolve(ctx EffectContext) []*Minion {
+	switch t.Type {
+	case TargetSelf:
+		if ctx.Source != nil && ctx.Source.IsAlive() {
+			return []*Minion{ctx.Source}
+		}
+		return nil
+	case TargetAllFriendly:
+		return t.limit(t.candidates(ctx.Board, ctx.Source, false))
+	case TargetAllEnemy:
+		return t.limit(t.candidates(ctx.OpponentBoard, ctx.Source, false))
+	case TargetRandomFriendly:
+		return t.pickRandom(ctx, t.candidates(ctx.Board, ctx.Source, false))
+	case TargetRandomEnemy:
+		return t.pickRandom(ctx, t.candidates(ctx.OpponentBoard, ctx.Source, true))
+	case TargetLeftFriendly:
+		return t.adjacent(ctx, -1)
+	case TargetRightFriendly:
+		return t.adjacent(ctx, 1)
+	case TargetLeftmostFriendly:
+		return t.limit(t.candidates(ctx.Board, ctx.Source, false))
+	case TargetRightmostFriendly:
+		c := t.candidates(ctx.Board, ctx.Source, false)
+		n := max(t.Count, 1)
+		if len(c) > n {
+			c = c[len(c)-n:]
+		}
+		return c
+	case TargetFriendlySelected:
+		m := ctx.Selected
+		if ctx.Board == nil || ctx.Board.IndexOf(m) < 0 || !m.IsAlive() || !t.Filter.Matches(m, ctx.Source) {
+			return nil
+		}
+		return []*Minion{m}
+	default:
+		return nil
+	}
+}
+
+// candidates returns living minions on b matching the filter, in board order.
+// Stealth minions are skipped when skipStealth is set (enemy targeting).
+func (t Target) candidates(b *Board, source *Minion, skipStealth bool) []*Minion {
+	if b == nil {
+		return nil
+	}
+	var res []*Minion
+	for _, m := range b.minions {
+		if !m.IsAlive() || !t.Filter.Matches(m, source) {
+			continue
+		}
+		if skipStealth && m.HasKeyword(KeywordStealth) {
+			continue
+		}
+		res = append(res, m)
+	}
+	return res
+}
+
+// limit truncates candidates to Count. Leftmost targets default to one minion,
+// other types treat Count 0 as all matching.
+func (t Target) limit(c []*Minion) []*Minion {
+	n := t.Count
+	if n == 0 && t.Type == TargetLeftmostFriendly {
+		n = 1
+	}
+	if n > 0 && len(c) > n {
+		return c[:n]
+	}
+	return c
+}
+
+// pickRandom picks Count distinct minions (at least one) from candidates.
+// In combat the pick uses the combat RNG so fights can be replayed.
+func (t Target) pickRandom(ctx EffectContext, c []*Minion) []*Minion {
+	n := max(t.Count, 1)
+	if len(c) <= n {
+		return c
+	}
+	swap := func(i, j int) { c[i], c[j] = c[j], c[i] }
+	if ctx.combat != nil {
+		ctx.combat.rng.Shuffle(len(c), swap)
+	} else {
+		rand.Shuffle(len(c), swap) //nolint:gosec // game logic, not crypto
+	}
+	return c[:n]
+}
+
+// adjacent returns the living friendly minion next to the source in direction dir.
+func (t Target) adjacent(ctx EffectContext, dir int) []*Minion {
+	if ctx.Board == nil || ctx.Source == nil {
+		return nil
+	}
+	idx := ctx.Board.IndexOf(ctx.Source)
+	if idx < 0 {
+		return nil
+	}
+	for i := idx + dir; i >= 0 && i < ctx.Board.Len(); i += dir {
+		m := ctx.Board.MinionAt(i)
+		if !m.IsAlive() {
+			continue // dead minions awaiting removal don't break adjacency
+		}
+		if !t.Filter.Matches(m, ctx.Source) {
+			return nil
+		}
+		return []*Minion{m}
+	}
+	return nil
+}
+
+// NeedsTarget reports whether playing the card asks the player to choose a
+// friendly minion: a spell aimed at one or a minion with a targeted Battlecry.
+func NeedsTarget(t CardTemplate) bool {
+	_, ok := selectedTarget(t.Effects(), playTrigger(t))
+	return ok
+}
+
+// playTrigger returns the trigger fired when a card of the template is played.
+func playTrigger(t CardTemplate) Trigger {
+	if t.Kind() == CardKindSpell {
+		return TriggerSpell
+	}
+	return TriggerBattlecry
+}
+
+// selectedTarget returns the Target of the first effect with one of the given
+// triggers that needs a player-chosen minion.
+func selectedTarget(effects []TriggeredEffect, triggers ...Trigger) (Target, bool) {
+	for e := range EffectsByTrigger(effects, triggers...) {
+		if te, ok := e.(targetedEffect); ok && te.target().Type == TargetFriendlySelected {
+			return te.target(), true
+		}
+	}
+	return Target{}, false
+}
@@ -0,0 +1,209 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testTemplate is a minimal CardTemplate for game package tests.
+type testTemplate struct {
+	id       string
+	kind     CardKind
+	tribes   Tribes
+	tier     Tier
+	attack   int
+	health   int
+	keywords Keywords
+	effects  []TriggeredEffect
+	auras    []Aura
+}
+
+func (t *testTemplate) ID() string                       { return t.id }
+func (t *testTemplate) Name() string                     { return t.id }
+func (t *testTemplate) Description() string              { return "" }
+func (t *testTemplate) Kind() CardKind                   { return t.kind }
+func (t *testTemplate) Tribes() Tribes                   { return t.tribes }
+func (t *testTemplate) Tier() Tier                       { return t.tier }
+func (t *testTemplate) Cost() int                        { return MinionCost }
+func (t *testTemplate) Attack() int                      { return t.attack }
+func (t *testTemplate) Health() int                      { return t.health }
+func (t *testTemplate) Keywords() Keywords               { return t.keywords }
+func (t *testTemplate) Effects() []TriggeredEffect       { return t.effects }
+func (t *testTemplate) GoldenEffects() []TriggeredEffect { return MakeGoldenEffects(t.effects) }
+func (t *testTemplate) Auras() []Aura                    { return t.auras }
+func (t *testTemplate) GoldenAuras() []Aura              { return MakeGoldenAuras(t.auras) }
+
+func testMinion(t *testing.T, id string, tribes Tribes, tier Tier, kws ...Keyword) *Minion {
+	t.Helper()
+	return NewMinion(&testTemplate{id: id, tribes: tribes, tier: tier, attack: 1, health: 1, keywords: NewKeywords(kws...)})
+}
+
+func testBoard(t *testing.T, minions ...*Minion) *Board {
+	t.Helper()
+	b := NewBoard(maxBoardSize)
+	for i, m := range minions {
+		b.PlaceMinion(m, i)
+	}
+	return &b
+}
+
+func minionIDs(minions []*Minion) []string {
+	var ids []string
+	for _, m := range minions {
+		ids = append(ids, m.TemplateID())
+	}
+	return ids
+}
+
+func TestTarget_Resolve(t *testing.T) {
+	t.Parallel()
+
+	beast := testMinion(t, "beast", NewTribes(TribeBeast), Tier1)
+	murloc := testMinion(t, "murloc", NewTribes(TribeMurloc), Tier2, KeywordTaunt)
+	source := testMinion(t, "source", NewTribes(TribeMurloc), Tier3)
+	amalgam := testMinion(t, "amalgam", TribeAll, Tier1)
+	dead := testMinion(t, "dead", NewTribes(TribeMurloc), Tier1)
+	dead.TakeDamage(1)
+
+	enemy := testMinion(t, "enemy", NewTribes(TribeDemon), Tier1)
+	stealthy := testMinion(t, "stealthy", NewTribes(TribeDemon), Tier1, KeywordStealth)
+
+	ctx := EffectContext{
+		Source:        source,
+		Board:         testBoard(t, beast, murloc, dead, source, amalgam),
+		OpponentBoard: testBoard(t, stealthy, enemy),
+	}
+
+	tests := []struct {
+		name   string
+		target Target
+		want   []string
+	}{
+		{
+			name:   "self",
+			target: Target{Type: TargetSelf},
+			want:   []string{"source"},
+		},
+		{
+			name:   "all friendly",
+			target: Target{Type: TargetAllFriendly},
+			want:   []string{"beast", "murloc", "source", "amalgam"},
+		},
+		{
+			name:   "all friendly exclude source",
+			target: Target{Type: TargetAllFriendly, Filter: TargetFilter{ExcludeSource: true}},
+			want:   []string{"beast", "murloc", "amalgam"},
+		},
+		{
+			name:   "tribe filter includes all-tribe",
+			target: Target{Type: TargetAllFriendly, Filter: TargetFilter{Tribe: TribeMurloc, ExcludeSource: true}},
+			want:   []string{"murloc", "amalgam"},
+		},
+		{
+			name:   "tier filter",
+			target: Target{Type: TargetAllFriendly, Filter: TargetFilter{Tier: Tier2}},
+			want:   []string{"murloc"},
+		},
+		{
+			name:   "keyword filter",
+			target: Target{Type: TargetAllFriendly, Filter: TargetFilter{HasKeyword: KeywordTaunt}},
+			want:   []string{"murloc"},
+		},
+		{
+			name:   "left skips dead",
+			target: Target{Type: TargetLeftFriendly},
+			want:   []string{"murloc"},
+		},
+		{
+			name:   "right",
+			target: Target{Type: TargetRightFriendly},
+			want:   []string{"amalgam"},
+		},
+		{
+			name:   "right filtered out",
+			target: Target{Type: TargetRightFriendly, Filter: TargetFilter{Tribe: TribeBeast, Tier: Tier2}},
+			want:   nil,
+		},
+		{
+			name:   "leftmost",
+			target: Target{Type: TargetLeftmostFriendly},
+			want:   []string{"beast"},
+		},
+		{
+			name:   "rightmost count",
+			target: Target{Type: TargetRightmostFriendly, Count: 2},
+			want:   []string{"source", "amalgam"},
+		},
+		{
+			name:   "all enemy includes stealth",
+			target: Target{Type: TargetAllEnemy},
+			want:   []string{"stealthy", "enemy"},
+		},
+		{
+			name:   "random enemy skips stealth",
+			target: Target{Type: TargetRandomEnemy, Count: 2},
+			want:   []string{"enemy"},
+		},
+		{
+			name:   "selected without choice",
+			target: Target{Type: TargetFriendlySelected},
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, minionIDs(tt.target.Resolve(ctx)))
+		})
+	}
+}
+
+func TestTarget_Resolve_RandomCount(t *testing.T) {
+	t.Parallel()
+
+	b := testBoard(t,
+		testMinion(t, "a", 0, Tier1),
+		testMinion(t, "b", 0, Tier1),
+		testMinion(t, "c", 0, Tier1),
+	)
+	ctx := EffectContext{Board: b}
+
+	assert.Len(t, Target{Type: TargetRandomFriendly}.Resolve(ctx), 1)
+	assert.Len(t, Target{Type: TargetRandomFriendly}.golden().Resolve(ctx), 2)
+	assert.Len(t, Target{Type: TargetRandomFriendly, Count: 5}.Resolve(ctx), 3)
+}
+
+func TestEffects_Apply(t *testing.T) {
+	t.Parallel()
+
+	source := testMinion(t, "source", NewTribes(TribeMurloc), Tier1)
+	murloc := testMinion(t, "murloc", NewTribes(TribeMurloc), Tier1, KeywordDivineShield)
+	beast := testMinion(t, "beast", NewTribes(TribeBeast), Tier1)
+	b := testBoard(t, source, murloc, beast)
+	ctx := EffectContext{Source: source, Board: b}
+
+	buff := &BuffStats{
+		Target: Target{Type: TargetAllFriendly, Filter: TargetFilter{Tribe: TribeMurloc, ExcludeSource: true}},
+		Attack: 2,
+		Health: 3,
+	}
+	assert.NoError(t, buff.Apply(ctx))
+	assert.Equal(t, 3, murloc.Attack())
+	assert.Equal(t, 4, murloc.Health())
+	assert.Equal(t, 1, source.Health())
+
+	give := &GiveKeyword{Target: Target{Type: TargetRightFriendly}, Keyword: KeywordTaunt}
+	assert.NoError(t, give.Apply(ctx))
+	assert.True(t, murloc.HasKeyword(KeywordTaunt))
+
+	dmg := &DealDamage{Target: Target{Type: TargetAllFriendly, Filter: TargetFilter{ExcludeSource: true}}, Amount: 1}
+	assert.NoError(t, dmg.Apply(ctx))
+	assert.False(t, murloc.HasKeyword(KeywordDivineShield))
+	assert.Equal(t, 4, murloc.Health())
+	assert.Equal(t, []string{"source", "murloc"}, minionIDs(b.Minions()), "dead beast removed outside combat")
+
+	destroy := &DestroyMinion{Target: Target{Type: TargetSelf}}
+	assert.NoError(t, destroy.Apply(ctx))
+	assert.Equal(t, []string{"murloc"}, minionIDs(b.Minions()))
+}
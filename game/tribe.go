@@ -1,6 +1,9 @@
 package game
 
-import "strings"
+import (
+	"math/rand/v2"
+	"strings"
+)
 
 // Tribe represents a single minion tribe.
 type Tribe uint8
@@ -69,6 +72,13 @@ func NewTribes(tribes ...Tribe) Tribes {
 // TribeAll is the bitmask containing all tribes.
 const TribeAll Tribes = (1 << (tribeMax - 1)) - 1
 
+// RandomTribes returns a set of n distinct tribes picked at random from the set.
+func RandomTribes(from Tribes, n int) Tribes {
+	all := from.List()
+	rand.Shuffle(len(all), func(i, j int) { all[i], all[j] = all[j], all[i] }) //nolint:gosec // game logic, not crypto
+	return NewTribes(all[:min(n, len(all))]...)
+}
+
 // Has returns true if the tribe is set.
 func (t Tribes) Has(tr Tribe) bool { return t&(1<<(tr-1)) != 0 }
 
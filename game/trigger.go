@@ -13,6 +13,7 @@ const (
 	TriggerEndOfTurn
 	TriggerSpell
 	TriggerGolden
+	TriggerHeroPower
 )
 
 func (t Trigger) String() string {
@@ -33,6 +34,8 @@ func (t Trigger) String() string {
 		return "Spell"
 	case TriggerGolden:
 		return "Golden"
+	case TriggerHeroPower:
+		return "Hero Power"
 	default:
 		return ""
 	}
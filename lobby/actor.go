@@ -0,0 +1,62 @@
+package lobby
+
+import "time"
+
+// command is a function run on the lobby goroutine. Its error is sent to done.
+type command struct {
+	fn   func(l *Lobby) error
+	done chan error
+}
+
+// Do runs fn on the lobby goroutine and returns its error. Commands run one at
+// a time in the order they are received, so fn has exclusive access to the
+// lobby and to its players. Returns ErrLobbyClosed if the lobby is closed.
+//
+// fn must not call Do on the same lobby.
+func (l *Lobby) Do(fn func(l *Lobby) error) error {
+	done := make(chan error, 1)
+	select {
+	case l.cmds <- command{fn: fn, done: done}:
+	case <-l.closed:
+		return ErrLobbyClosed
+	}
+	return <-done
+}
+
+// Close stops the lobby goroutine. Later Do calls return ErrLobbyClosed.
+func (l *Lobby) Close() {
+	l.closeOnce.Do(func() { close(l.closed) })
+}
+
+// run executes commands until the lobby is closed. After every command and
+// when the phase timer fires it advances finished phases and arms the timer
+// for the end of the current one. The timer is disarmed while no game is
+// running, so idle lobbies only wait for commands.
+func (l *Lobby) run() {
+	timer := time.NewTimer(0)
+	timer.Stop()
+	defer timer.Stop()
+
+	var phaseEnded <-chan time.Time
+	for {
+		select {
+		case cmd := <-l.cmds:
+			cmd.done <- cmd.fn(l)
+		case <-phaseEnded:
+		case <-l.closed:
+			return
+		}
+
+		for l.advancePhase() {
+			if l.onPhase != nil {
+				l.onPhase(l)
+			}
+		}
+
+		phaseEnded = nil
+		if l.state == StatePlaying {
+			timer.Reset(time.Until(l.phaseEndsAt))
+			phaseEnded = timer.C
+		}
+	}
+}
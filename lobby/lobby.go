@@ -1,9 +1,11 @@
 package lobby
 
 import (
+	"log/slog"
 	"math/rand/v2"
 	"slices"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/ysomad/gigabg/game"
@@ -20,6 +22,11 @@ const (
 	ErrNotEnoughPlayers   errors.Error = "not enough players"
 	ErrInvalidPlayerCount errors.Error = "max players must be even, between 2 and 8"
 	ErrAlreadyConnected   errors.Error = "player already connected"
+	ErrNotHeroSelect      errors.Error = "not in hero selection"
+	ErrInvalidHeroIndex   errors.Error = "invalid hero index"
+	ErrInvalidTribes      errors.Error = "invalid tribes"
+	ErrLobbyClosed        errors.Error = "lobby closed"
+	ErrNotRecruit         errors.Error = "not in recruit phase"
 )
 
 type State uint8
@@ -45,12 +52,23 @@ func (s State) String() string {
 
 const maxCombatLogs = 3
 
+const heroOfferCount = 3 // heroes offered to each player at game start
+
 type CombatPairing struct {
 	Opponent      game.PlayerID
 	PlayerBoard   game.Board // cloned with combat IDs
 	OpponentBoard game.Board // cloned with combat IDs
 }
 
+// ghost is a player's board as it was at their last combat. The board of the
+// most recently eliminated player is fought by the leftover player when an
+// odd number of players are alive.
+type ghost struct {
+	player game.PlayerID
+	board  game.Board
+	tier   game.Tier
+}
+
 func newCombatPairing(opponent game.PlayerID, pb, ob game.Board) CombatPairing {
 	return CombatPairing{
 		Opponent:      opponent,
@@ -59,45 +77,93 @@ func newCombatPairing(opponent game.PlayerID, pb, ob game.Board) CombatPairing {
 	}
 }
 
+// Lobby is a single game. Its state is owned by the lobby goroutine: callers
+// other than the lobby itself must access it only inside Do. ID, SetID,
+// SetPhaseHandler and MaxPlayers are safe to call before the lobby is shared,
+// ID, MaxPlayers and CreatedAt at any time.
 type Lobby struct {
+	cmds      chan command
+	closed    chan struct{}
+	closeOnce sync.Once
+	onPhase   func(l *Lobby) // called after every phase change
+
 	id         string
 	state      State
 	maxPlayers int
 	players    []*game.Player
+	cards      game.CardCatalog
+	heroes     game.HeroCatalog
 	pool       *game.CardPool
 	turn       int
+	damageCaps game.DamageCaps
 
 	phase       game.Phase
-	phaseEndsAt time.Time // when current phase ends
+	phaseEndsAt time.Time              // when current phase ends
+	ready       map[game.PlayerID]bool // players done with the recruit phase
 
 	combatResults  map[game.PlayerID][]game.CombatResult // playerID -> last N results
-	combatLogs     []game.CombatLog                      // ephemeral, cleared after send
+	combatLogs     []game.CombatLog                      // combat phase only
 	combatPairings map[game.PlayerID]CombatPairing       // playerID -> pairing, combat phase only
 	nextPairings   map[game.PlayerID]game.PlayerID       // playerID -> next opponentID, recruit phase only
 
-	topTribes map[game.PlayerID]game.TopTribe // playerID -> snapshot from last combat
+	topTribes  map[game.PlayerID]game.TopTribe       // playerID -> snapshot from last combat
+	heroOffers map[game.PlayerID][]game.HeroTemplate // playerID -> unpicked offers, hero select phase only
 
+	lastBoards   map[game.PlayerID]ghost // playerID -> board at last combat
+	ghost        *ghost                  // most recently eliminated player, nil until someone is eliminated
+	ghostFighter game.PlayerID           // player paired with the ghost, 0 if none
+
+	createdAt  time.Time
 	startedAt  time.Time
 	eliminated int              // number of eliminated players
 	gameResult *game.GameResult // set when game finishes
 }
 
-func New(cards game.CardCatalog, maxPlayers int) (*Lobby, error) {
+// New creates a lobby whose card pool holds minions of the given tribes.
+// Zero tribes picks game.LobbyTribes of the catalog's tribes at random.
+// It starts the lobby goroutine, so the caller must Close the lobby or hand
+// it to a Store, which closes it on DeleteLobby.
+func New(cards game.CardCatalog, heroes game.HeroCatalog, maxPlayers int, tribes game.Tribes) (*Lobby, error) {
 	if maxPlayers < game.MinPlayers || maxPlayers > game.MaxPlayers || maxPlayers%2 != 0 {
 		return nil, ErrInvalidPlayerCount
 	}
-	return &Lobby{
+	if tribes&^game.TribeAll != 0 {
+		return nil, ErrInvalidTribes
+	}
+	if tribes == 0 {
+		tribes = game.RandomTribes(game.CardTribes(cards), game.LobbyTribes)
+	}
+	l := &Lobby{
+		cmds:       make(chan command),
+		closed:     make(chan struct{}),
 		id:         strconv.Itoa(rand.IntN(100_000_000)),
 		state:      StateWaiting,
 		maxPlayers: maxPlayers,
 		players:    make([]*game.Player, 0, maxPlayers),
-		pool:       game.NewCardPool(cards, maxPlayers),
-	}, nil
+		cards:      cards,
+		heroes:     heroes,
+		pool:       game.NewTribeCardPool(cards, maxPlayers, tribes),
+		damageCaps: game.DefaultDamageCaps,
+		ready:      make(map[game.PlayerID]bool, maxPlayers),
+		createdAt:  time.Now(),
+	}
+	go l.run()
+	return l, nil
 }
 
 func (l *Lobby) ID() string      { return l.id }
 func (l *Lobby) SetID(id string) { l.id = id }
 
+// SetDamageCaps replaces the per-turn combat damage caps. Nil disables capping.
+func (l *Lobby) SetDamageCaps(caps game.DamageCaps) { l.damageCaps = caps }
+
+// SetPhaseHandler sets fn to be called on the lobby goroutine after every
+// phase change. fn must not call Do on the lobby.
+func (l *Lobby) SetPhaseHandler(fn func(l *Lobby)) { l.onPhase = fn }
+
+// CreatedAt returns when the lobby was created.
+func (l *Lobby) CreatedAt() time.Time { return l.createdAt }
+
 // MaxPlayers returns the lobby's max player count.
 func (l *Lobby) MaxPlayers() int { return l.maxPlayers }
 
@@ -128,16 +194,77 @@ func (l *Lobby) start() {
 	l.state = StatePlaying
 	l.turn = 1
 	l.startedAt = time.Now()
+	l.startHeroSelect()
+}
+
+// startHeroSelect offers each player a few random heroes before turn 1.
+// Offers may overlap between players when there are few heroes.
+func (l *Lobby) startHeroSelect() {
+	heroes := l.heroes.Heroes()
+	if len(heroes) == 0 {
+		l.startRecruit()
+		return
+	}
+
+	l.phase = game.PhaseHeroSelect
+	l.phaseEndsAt = time.Now().Add(game.HeroSelectDuration)
+	l.heroOffers = make(map[game.PlayerID][]game.HeroTemplate, len(l.players))
+
+	for _, p := range l.players {
+		rand.Shuffle(len(heroes), func(i, j int) { heroes[i], heroes[j] = heroes[j], heroes[i] })
+		l.heroOffers[p.ID()] = slices.Clone(heroes[:min(heroOfferCount, len(heroes))])
+	}
+}
+
+// endHeroSelect assigns a random offered hero to players who didn't pick one
+// and starts the first recruit phase.
+func (l *Lobby) endHeroSelect() {
+	for id, offers := range l.heroOffers {
+		if p := l.Player(id); p != nil {
+			p.SetHero(offers[rand.IntN(len(offers))])
+		}
+	}
+	l.heroOffers = nil
 	l.startRecruit()
 }
 
+// PickHero assigns the offered hero at index to the player.
+func (l *Lobby) PickHero(player game.PlayerID, index int) error {
+	if l.phase != game.PhaseHeroSelect {
+		return ErrNotHeroSelect
+	}
+	p := l.Player(player)
+	if p == nil {
+		return ErrNotAllowed
+	}
+	offers, ok := l.heroOffers[player]
+	if !ok || index < 0 || index >= len(offers) {
+		return ErrInvalidHeroIndex
+	}
+	p.SetHero(offers[index])
+	delete(l.heroOffers, player)
+	return nil
+}
+
+// HeroOffers returns the heroes the player may still pick from (hero select phase only).
+func (l *Lobby) HeroOffers(player game.PlayerID) []game.HeroTemplate {
+	return l.heroOffers[player]
+}
+
 func (l *Lobby) startRecruit() {
 	l.phase = game.PhaseRecruit
-	l.phaseEndsAt = time.Now().Add(game.RecruitDuration)
+	l.phaseEndsAt = time.Now().Add(game.RecruitDuration(l.turn))
+	l.combatLogs = nil
+	clear(l.ready)
 	l.computeNextPairings()
 
 	for _, p := range l.players {
-		p.StartTurn(l.pool, l.turn)
+		if !p.IsAlive() {
+			continue
+		}
+		if err := p.StartTurn(l.pool, l.turn); err != nil {
+			slog.Error("start of turn effects", "lobby", l.id, "player", p.ID(), "error", err)
+		}
 	}
 }
 
@@ -200,23 +327,44 @@ func (l *Lobby) computeNextPairings() {
 			paired[fallback.ID()] = struct{}{}
 		}
 	}
+
+	// With an odd number alive the leftover player fights the ghost.
+	l.ghostFighter = 0
+	if l.ghost == nil {
+		return
+	}
+	for _, p := range alive {
+		if _, ok := paired[p.ID()]; !ok {
+			l.nextPairings[p.ID()] = l.ghost.player
+			l.ghostFighter = p.ID()
+		}
+	}
 }
 
 // NextOpponent returns the pre-determined next opponent for the given player.
+// For the player paired with the ghost it is the eliminated player's ID.
 func (l *Lobby) NextOpponent(player game.PlayerID) game.PlayerID {
 	return l.nextPairings[player]
 }
 
+// FightsGhost reports whether the player's next (or current) opponent is the
+// ghost of an eliminated player.
+func (l *Lobby) FightsGhost(player game.PlayerID) bool {
+	return l.ghostFighter != 0 && l.ghostFighter == player
+}
+
 func (l *Lobby) startCombat() {
 	l.phase = game.PhaseCombat
-	l.phaseEndsAt = time.Now().Add(game.CombatDuration)
+	l.endTurn()
 	l.resolveDiscovers()
 	l.runCombat()
 
 	// Skip combat timer if no pairing had minions on both sides.
 	if l.isCombatTrivial() {
 		l.phaseEndsAt = time.Now()
+		return
 	}
+	l.phaseEndsAt = time.Now().Add(game.CombatDuration(l.combatLogs))
 }
 
 // isCombatTrivial returns true if no combat animation had any events (no real fights).
@@ -243,22 +391,53 @@ func (l *Lobby) runCombat() {
 
 	// Use pre-computed pairings from recruit phase.
 	resolved := make(map[game.PlayerID]struct{}, len(l.nextPairings))
+	var eliminated []*game.Player
 	for pid, oid := range l.nextPairings {
 		if _, ok := resolved[pid]; ok {
 			continue
 		}
+		if pid == l.ghostFighter {
+			if p := l.Player(pid); p != nil && p.IsAlive() {
+				if l.resolveGhost(p) {
+					eliminated = append(eliminated, p)
+				}
+			}
+			resolved[pid] = struct{}{}
+			continue
+		}
 		p1 := l.Player(pid)
 		p2 := l.Player(oid)
 		if p1 != nil && p2 != nil && p1.IsAlive() && p2.IsAlive() {
-			l.resolvePairing(p1, p2)
+			if loser := l.resolvePairing(p1, p2); loser != nil {
+				eliminated = append(eliminated, loser)
+			}
 			resolved[pid] = struct{}{}
 			resolved[oid] = struct{}{}
 		}
 	}
 
+	l.placeEliminated(eliminated)
 	l.checkFinished()
 }
 
+// placeEliminated assigns placements to players eliminated in the same combat
+// round. The player left with the lowest combined health and armor places last.
+func (l *Lobby) placeEliminated(players []*game.Player) {
+	slices.SortFunc(players, func(a, b *game.Player) int {
+		return a.Health() - b.Health()
+	})
+	for _, p := range players {
+		l.eliminated++
+		p.SetPlacement(len(l.players) - l.eliminated + 1)
+	}
+	if len(players) == 0 {
+		return
+	}
+	if g, ok := l.lastBoards[players[len(players)-1].ID()]; ok {
+		l.ghost = &g
+	}
+}
+
 func (l *Lobby) checkFinished() {
 	var alive int
 	var winner *game.Player
@@ -308,34 +487,73 @@ func (l *Lobby) checkFinished() {
 	}
 }
 
-func (l *Lobby) resolvePairing(p1, p2 *game.Player) {
+// resolvePairing runs combat between p1 and p2 and applies damage to the
+// loser. Returns the loser if the damage eliminated them.
+func (l *Lobby) resolvePairing(p1, p2 *game.Player) *game.Player {
 	l.snapshotTribe(p1)
 	l.snapshotTribe(p2)
+	l.snapshotBoard(p1)
+	l.snapshotBoard(p2)
 
-	combat := game.NewCombat(p1, p2)
+	seed := rand.Uint64() //nolint:gosec // game logic, not crypto
+	combat := game.NewCombat(p1, p2, l.cards, seed)
+	slog.Debug("combat", "lobby", l.id, "player1", p1.ID(), "player2", p2.ID(), "seed", seed)
 
 	p1Board, p2Board := combat.Boards()
 	l.combatPairings[p1.ID()] = newCombatPairing(p2.ID(), p1Board, p2Board)
 	l.combatPairings[p2.ID()] = newCombatPairing(p1.ID(), p2Board, p1Board)
 
 	r1, r2 := combat.Run()
-
-	if r1.Winner != 0 && r1.Damage > 0 {
-		loser := p2
-		if r1.Winner == p2.ID() {
-			loser = p1
-		}
-		if loser.IsAlive() {
-			if loser.TakeDamage(r1.Damage) {
-				l.eliminated++
-				loser.SetPlacement(len(l.players) - l.eliminated + 1)
-			}
-		}
-	}
+	r1.Damage = l.damageCaps.Apply(l.turn, r1.RawDamage)
+	r2.Damage = r1.Damage
 
 	l.appendCombatResult(p1.ID(), r1)
 	l.appendCombatResult(p2.ID(), r2)
 	l.combatLogs = append(l.combatLogs, combat.Log())
+
+	if r1.Winner == 0 || r1.Damage <= 0 {
+		return nil
+	}
+	loser := p2
+	if r1.Winner == p2.ID() {
+		loser = p1
+	}
+	if loser.IsAlive() && loser.TakeDamage(r1.Damage) {
+		return loser
+	}
+	return nil
+}
+
+// resolveGhost runs combat between p and the ghost. Damage is applied only
+// to p. Returns true if the damage eliminated p.
+func (l *Lobby) resolveGhost(p *game.Player) bool {
+	l.snapshotTribe(p)
+	l.snapshotBoard(p)
+
+	g := game.NewGhost(l.ghost.player, l.ghost.board, l.ghost.tier)
+	seed := rand.Uint64() //nolint:gosec // game logic, not crypto
+	combat := game.NewCombat(p, g, l.cards, seed)
+	slog.Debug("ghost combat", "lobby", l.id, "player", p.ID(), "ghost", g.ID(), "seed", seed)
+
+	pBoard, gBoard := combat.Boards()
+	l.combatPairings[p.ID()] = newCombatPairing(g.ID(), pBoard, gBoard)
+
+	r, _ := combat.Run()
+	r.Damage = l.damageCaps.Apply(l.turn, r.RawDamage)
+
+	l.appendCombatResult(p.ID(), r)
+	l.combatLogs = append(l.combatLogs, combat.Log())
+
+	return r.Winner == g.ID() && r.Damage > 0 && p.TakeDamage(r.Damage)
+}
+
+// snapshotBoard records the player's board before combat so it can be fought
+// as a ghost after elimination.
+func (l *Lobby) snapshotBoard(p *game.Player) {
+	if l.lastBoards == nil {
+		l.lastBoards = make(map[game.PlayerID]ghost, len(l.players))
+	}
+	l.lastBoards[p.ID()] = ghost{player: p.ID(), board: p.Board(), tier: p.Shop().Tier()}
 }
 
 func (l *Lobby) snapshotTribe(p *game.Player) {
@@ -357,6 +575,19 @@ func (l *Lobby) appendCombatResult(player game.PlayerID, result game.CombatResul
 	l.combatResults[player] = logs
 }
 
+// endTurn fires End of Turn effects for living players before combat
+// snapshots their boards.
+func (l *Lobby) endTurn() {
+	for _, p := range l.players {
+		if !p.IsAlive() {
+			continue
+		}
+		if err := p.EndTurn(l.pool); err != nil {
+			slog.Error("end of turn effects", "lobby", l.id, "player", p.ID(), "error", err)
+		}
+	}
+}
+
 // resolveDiscovers auto-picks a random discover option for players who
 // didn't pick before combat started, so the spell isn't wasted.
 // Unpicked options are returned to the pool.
@@ -366,20 +597,58 @@ func (l *Lobby) resolveDiscovers() {
 	}
 }
 
-// AdvancePhase checks if phase should advance and does so.
+// SetReady marks the player as done with the recruit phase. The phase ends
+// early once all living players are ready.
+func (l *Lobby) SetReady(player game.PlayerID, ready bool) error {
+	if l.phase != game.PhaseRecruit {
+		return ErrNotRecruit
+	}
+	if l.Player(player) == nil {
+		return ErrNotAllowed
+	}
+	l.ready[player] = ready
+	return nil
+}
+
+// Ready reports whether the player is done with the recruit phase.
+func (l *Lobby) Ready(player game.PlayerID) bool { return l.ready[player] }
+
+// ReadyPlayers returns playerID -> whether the player is done with the recruit phase.
+func (l *Lobby) ReadyPlayers() map[game.PlayerID]bool { return l.ready }
+
+// phaseDone reports whether all players are done with the current phase,
+// so it can end before phaseEndsAt.
+func (l *Lobby) phaseDone() bool {
+	switch l.phase {
+	case game.PhaseHeroSelect:
+		return len(l.heroOffers) == 0
+	case game.PhaseRecruit:
+		for _, p := range l.players {
+			if p.IsAlive() && !l.ready[p.ID()] {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// advancePhase advances the phase if it's over or all players are done.
 // Returns true if phase changed.
-func (l *Lobby) AdvancePhase() bool {
+func (l *Lobby) advancePhase() bool {
 	if l.state != StatePlaying {
 		return false
 	}
-
-	if time.Now().Before(l.phaseEndsAt) {
+	if time.Now().Before(l.phaseEndsAt) && !l.phaseDone() {
 		return false
 	}
 
 	switch l.phase {
 	case game.PhaseWaiting, game.PhaseFinished:
 		return false
+	case game.PhaseHeroSelect:
+		l.endHeroSelect()
 	case game.PhaseRecruit:
 		l.startCombat()
 	case game.PhaseCombat:
@@ -426,11 +695,21 @@ func (l *Lobby) CombatResults(player game.PlayerID) []game.CombatResult {
 // AllCombatResults returns combat results for all players.
 func (l *Lobby) AllCombatResults() map[game.PlayerID][]game.CombatResult { return l.combatResults }
 
-// CombatLogs returns pending combat logs and clears them.
-func (l *Lobby) CombatLogs() []game.CombatLog {
-	logs := l.combatLogs
-	l.combatLogs = nil
-	return logs
+// CombatLogs returns the combat logs of the current combat phase.
+func (l *Lobby) CombatLogs() []game.CombatLog { return l.combatLogs }
+
+// CombatLog returns the log of the player's fight in the current combat
+// phase. Ghost owners have no fight.
+func (l *Lobby) CombatLog(player game.PlayerID) (game.CombatLog, bool) {
+	if _, ok := l.combatPairings[player]; !ok {
+		return game.CombatLog{}, false
+	}
+	for _, log := range l.combatLogs {
+		if log.Player1 == player || log.Player2 == player {
+			return log, true
+		}
+	}
+	return game.CombatLog{}, false
 }
 
 // CombatPairing returns the combat pairing for the given player (combat phase only).
@@ -442,5 +721,8 @@ func (l *Lobby) CombatPairing(player game.PlayerID) (CombatPairing, bool) {
 // GameResult returns the game result, or nil if the game hasn't finished.
 func (l *Lobby) GameResult() *game.GameResult { return l.gameResult }
 
+// Tribes returns the tribes whose minions are in the lobby's card pool.
+func (l *Lobby) Tribes() game.Tribes { return l.pool.Tribes() }
+
 // Pool returns the card pool.
 func (l *Lobby) Pool() *game.CardPool { return l.pool }
@@ -0,0 +1,240 @@
+package lobby
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ysomad/gigabg/game"
+	"github.com/ysomad/gigabg/game/catalog"
+)
+
+func TestLobby_DoConcurrent(t *testing.T) {
+	t.Parallel()
+
+	cards, err := catalog.New()
+	require.NoError(t, err)
+	l, err := New(cards, cards, game.MaxPlayers, 0)
+	require.NoError(t, err)
+	defer l.Close()
+
+	// Phases are advanced by the lobby goroutine, concurrently with the
+	// players' actions below.
+	var combats atomic.Int32
+	l.SetPhaseHandler(func(l *Lobby) {
+		if l.Phase() == game.PhaseCombat {
+			combats.Add(1)
+		}
+	})
+
+	var wg sync.WaitGroup
+	for i := range game.MaxPlayers {
+		id := game.PlayerID(i + 1)
+		wg.Go(func() {
+			assert.NoError(t, l.Do(func(l *Lobby) error { return l.AddPlayer(id) }))
+			for j := range 200 {
+				_ = l.Do(func(l *Lobby) error {
+					if l.Phase() == game.PhaseHeroSelect {
+						return l.PickHero(id, 0)
+					}
+					p := l.Player(id)
+					if p == nil || l.Phase() != game.PhaseRecruit {
+						return nil
+					}
+					_ = p.BuyCard(0)
+					_ = p.PlayMinion(0, 0, -1, l.Pool())
+					_ = p.UpgradeShop()
+					if j%10 == 9 {
+						return l.SetReady(id, true)
+					}
+					return p.RefreshShop(l.Pool())
+				})
+			}
+		})
+	}
+	wg.Wait()
+
+	// Players may have ended the loop before the lobby was full or before
+	// all of them were ready.
+	require.Eventually(t, func() bool {
+		_ = l.Do(func(l *Lobby) error {
+			for i := range game.MaxPlayers {
+				id := game.PlayerID(i + 1)
+				_ = l.PickHero(id, 0)
+				_ = l.SetReady(id, true)
+			}
+			return nil
+		})
+		return combats.Load() > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	l.Close()
+	assert.ErrorIs(t, l.Do(func(*Lobby) error { return nil }), ErrLobbyClosed)
+}
+
+func TestLobby_PhaseEndsWhenDone(t *testing.T) {
+	t.Parallel()
+
+	cards, err := catalog.New()
+	require.NoError(t, err)
+	l, err := New(cards, cards, game.MinPlayers, 0)
+	require.NoError(t, err)
+	defer l.Close()
+
+	phases := make(chan game.Phase, 8)
+	l.SetPhaseHandler(func(l *Lobby) { phases <- l.Phase() })
+
+	players := []game.PlayerID{1, 2}
+	for _, id := range players {
+		require.NoError(t, l.Do(func(l *Lobby) error { return l.AddPlayer(id) }))
+	}
+	for _, id := range players {
+		require.NoError(t, l.Do(func(l *Lobby) error { return l.PickHero(id, 0) }))
+	}
+	assert.Equal(t, game.PhaseRecruit, <-phases)
+
+	for _, id := range players {
+		require.NoError(t, l.Do(func(l *Lobby) error { return l.SetReady(id, true) }))
+	}
+	// Combat between empty boards is trivial and ends right away.
+	assert.Equal(t, game.PhaseCombat, <-phases)
+	assert.Equal(t, game.PhaseRecruit, <-phases)
+
+	require.NoError(t, l.Do(func(l *Lobby) error {
+		assert.Equal(t, 2, l.Turn())
+		assert.False(t, l.Ready(1))
+		return nil
+	}))
+}
+
+// growTemplate is a tier 1 minion gaining +1/+1 at the start of every turn.
+type growTemplate struct{}
+
+func (growTemplate) ID() string               { return "grow" }
+func (growTemplate) Name() string             { return "Grow" }
+func (growTemplate) Description() string      { return "" }
+func (growTemplate) Kind() game.CardKind      { return game.CardKindMinion }
+func (growTemplate) Tribes() game.Tribes      { return 0 }
+func (growTemplate) Tier() game.Tier          { return game.Tier1 }
+func (growTemplate) Cost() int                { return 3 }
+func (growTemplate) Attack() int              { return 1 }
+func (growTemplate) Health() int              { return 1 }
+func (growTemplate) Keywords() game.Keywords  { return game.NewKeywords() }
+func (growTemplate) Auras() []game.Aura       { return nil }
+func (growTemplate) GoldenAuras() []game.Aura { return nil }
+
+func (growTemplate) Effects() []game.TriggeredEffect {
+	return []game.TriggeredEffect{{
+		Trigger: game.TriggerStartOfTurn,
+		Effect:  &game.BuffStats{Target: game.Target{Type: game.TargetSelf}, Attack: 1, Health: 1, Persistent: true},
+	}}
+}
+
+func (t growTemplate) GoldenEffects() []game.TriggeredEffect {
+	return game.MakeGoldenEffects(t.Effects())
+}
+
+// growCatalog holds growTemplate as its only card.
+type growCatalog struct{}
+
+func (growCatalog) ByTemplateID(id string) game.CardTemplate {
+	if id == "grow" {
+		return growTemplate{}
+	}
+	return nil
+}
+
+func (growCatalog) ByKindTierTribe(kind game.CardKind, tier game.Tier, _ game.Tribe) []game.CardTemplate {
+	if kind == game.CardKindMinion && tier == game.Tier1 {
+		return []game.CardTemplate{growTemplate{}}
+	}
+	return nil
+}
+
+func TestLobby_startRecruit_SkipsEliminated(t *testing.T) {
+	t.Parallel()
+
+	heroes, err := catalog.New()
+	require.NoError(t, err)
+	// A full lobby so the pool has copies of the minion for two shops.
+	l, err := New(growCatalog{}, heroes, game.MaxPlayers, game.TribeAll)
+	require.NoError(t, err)
+	defer l.Close()
+
+	players := make([]game.PlayerID, game.MaxPlayers)
+	for i := range players {
+		players[i] = game.PlayerID(i + 1)
+	}
+	for _, id := range players {
+		require.NoError(t, l.Do(func(l *Lobby) error { return l.AddPlayer(id) }))
+	}
+	for _, id := range players {
+		require.NoError(t, l.Do(func(l *Lobby) error { return l.PickHero(id, 0) }))
+	}
+
+	var aliveAttack, deadAttack int
+	require.NoError(t, l.Do(func(l *Lobby) error {
+		if l.Phase() != game.PhaseRecruit {
+			return fmt.Errorf("phase %s, want recruit", l.Phase())
+		}
+		for _, id := range players[:2] {
+			p := l.Player(id)
+			if err := p.BuyCard(0); err != nil {
+				return err
+			}
+			if err := p.PlayMinion(0, 0, -1, l.Pool()); err != nil {
+				return err
+			}
+		}
+		dead := l.Player(2)
+		dead.TakeDamage(dead.Health())
+
+		l.startRecruit()
+
+		aliveAttack = l.Player(1).Board().MinionAt(0).Attack()
+		deadAttack = dead.Board().MinionAt(0).Attack()
+		return nil
+	}))
+	assert.Equal(t, 2, aliveAttack, "living player's minion grows")
+	assert.Equal(t, 1, deadAttack, "eliminated player's minion doesn't fire")
+}
+
+func TestLobby_resolveGhost_Result(t *testing.T) {
+	t.Parallel()
+
+	cards, err := catalog.New()
+	require.NoError(t, err)
+	l, err := New(cards, cards, game.MinPlayers, 0)
+	require.NoError(t, err)
+	defer l.Close()
+
+	var results []game.CombatResult
+	require.NoError(t, l.Do(func(l *Lobby) error {
+		l.SetDamageCaps(nil)
+		l.combatResults = make(map[game.PlayerID][]game.CombatResult)
+		l.combatPairings = make(map[game.PlayerID]CombatPairing)
+
+		board := game.NewBoard(0)
+		board.PlaceMinion(game.NewMinion(growTemplate{}), 0)
+		l.ghost = &ghost{player: 9, board: board, tier: game.Tier1}
+
+		// Sides are picked at random, so the ghost attacks last in some runs.
+		for range 32 {
+			p := game.NewPlayer(1)
+			l.resolveGhost(p)
+			rs := l.CombatResults(p.ID())
+			results = append(results, rs[len(rs)-1])
+			delete(l.combatResults, p.ID())
+		}
+		return nil
+	}))
+
+	for _, r := range results {
+		assert.Equal(t, game.CombatResult{Opponent: 9, Winner: 9, Damage: 2, RawDamage: 2}, r)
+	}
+}
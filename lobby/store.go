@@ -2,6 +2,7 @@ package lobby
 
 import (
 	"log/slog"
+	"slices"
 	"sync"
 )
 
@@ -44,15 +45,32 @@ func (s *MemoryStore) Lobby(lobbyID string) (*Lobby, error) {
 	return l, nil
 }
 
+// Lobbies returns all lobbies, oldest first.
+func (s *MemoryStore) Lobbies() []*Lobby {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	lobbies := make([]*Lobby, 0, len(s.lobbies))
+	for _, l := range s.lobbies {
+		lobbies = append(lobbies, l)
+	}
+	slices.SortFunc(lobbies, func(a, b *Lobby) int {
+		return a.CreatedAt().Compare(b.CreatedAt())
+	})
+	return lobbies
+}
+
 func (s *MemoryStore) DeleteLobby(lobbyID string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if _, exists := s.lobbies[lobbyID]; !exists {
+	l, exists := s.lobbies[lobbyID]
+	if !exists {
 		return ErrLobbyNotFound
 	}
 
 	delete(s.lobbies, lobbyID)
+	l.Close()
 
 	slog.Info("lobby deleted", "id", lobbyID)
 
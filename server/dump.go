@@ -0,0 +1,49 @@
+package server
+
+import (
+	json "encoding/json/v2"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/ysomad/gigabg/api"
+	"github.com/ysomad/gigabg/game"
+	"github.com/ysomad/gigabg/lobby"
+)
+
+// SetCombatDumpDir makes the server write every combat pairing to dir as an
+// api.CombatSnapshot, to replay real fights with cmd/simulate. Empty disables
+// dumping. Must be called before the server starts serving.
+func (s *Server) SetCombatDumpDir(dir string) {
+	s.combatDumpDir = dir
+}
+
+// dumpCombats writes a snapshot file per player fighting this turn, named
+// <lobby>-turn<turn>-player<player>.json. Runs on the lobby goroutine.
+func (s *Server) dumpCombats(l *lobby.Lobby) {
+	for _, p := range l.Players() {
+		pair, ok := l.CombatPairing(p.ID())
+		if !ok {
+			continue
+		}
+
+		// The ghost of an eliminated player fights with its owner's last tier.
+		var opponentTier game.Tier
+		if opp := l.Player(pair.Opponent); opp != nil {
+			opponentTier = opp.Shop().Tier()
+		}
+
+		snap := api.NewCombatSnapshot(pair.PlayerBoard, pair.OpponentBoard, p.Shop().Tier(), opponentTier)
+		data, err := json.Marshal(snap)
+		if err != nil {
+			slog.Error("encode combat snapshot", "error", err, "lobby", l.ID(), "player", p.ID())
+			continue
+		}
+
+		name := filepath.Join(s.combatDumpDir, fmt.Sprintf("%s-turn%d-player%d.json", l.ID(), l.Turn(), p.ID()))
+		if err := os.WriteFile(name, data, 0o644); err != nil { //nolint:gosec // debug output
+			slog.Error("write combat snapshot", "error", err, "file", name)
+		}
+	}
+}
@@ -0,0 +1,343 @@
+package server
+
+import (
+	"crypto/rand"
+	json "encoding/json/v2"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ysomad/gigabg/api"
+	"github.com/ysomad/gigabg/game"
+	"github.com/ysomad/gigabg/lobby"
+)
+
+const (
+	queueTimeout  = time.Minute     // queued this long, the player is offered a smaller lobby
+	queuePollWait = 4 * time.Second // long poll, under the HTTP write timeout
+	ticketTTL     = 3 * queuePollWait
+)
+
+var (
+	errTicketNotFound = errors.New("ticket not found")
+	errAlreadyQueued  = errors.New("player already queued")
+	errQueueNotTimed  = errors.New("queue hasn't timed out yet")
+)
+
+// ticket is a player's place in the matchmaking queue.
+type ticket struct {
+	id         string
+	player     game.PlayerID
+	size       int // requested lobby size
+	enqueuedAt time.Time
+	polledAt   time.Time
+	smaller    bool          // accepts a smaller lobby after queueTimeout
+	matching   bool          // taken from the queue, its lobby is being created
+	found      chan struct{} // closed once lobbyID and token are set
+	lobbyID    string
+	token      string
+}
+
+// matchmaker groups queued players into new lobbies. The game has no bots,
+// so players queued for too long are only offered a smaller lobby.
+type matchmaker struct {
+	mu      sync.Mutex
+	tickets map[string]*ticket
+	queues  map[int][]*ticket // lobby size -> unmatched tickets, oldest first
+}
+
+func newMatchmaker() *matchmaker {
+	return &matchmaker{
+		tickets: make(map[string]*ticket),
+		queues:  make(map[int][]*ticket),
+	}
+}
+
+// match returns the current state of the ticket.
+func (m *matchmaker) match(t *ticket) api.Match {
+	select {
+	case <-t.found:
+		return api.Match{State: api.MatchFound, LobbyID: t.lobbyID, Token: t.token}
+	default:
+	}
+	state := api.MatchQueued
+	if time.Since(t.enqueuedAt) >= queueTimeout {
+		state = api.MatchTimedOut
+	}
+	return api.Match{State: state, Queued: len(m.queues[t.size])}
+}
+
+// sweep drops tickets whose players stopped polling. Must hold m.mu.
+func (m *matchmaker) sweep() {
+	for id, t := range m.tickets {
+		if t.matching || time.Since(t.polledAt) < ticketTTL {
+			continue
+		}
+		delete(m.tickets, id)
+		m.queues[t.size] = slices.DeleteFunc(m.queues[t.size], func(q *ticket) bool { return q == t })
+		slog.Info("ticket expired", "ticket", id, "player", t.player)
+	}
+}
+
+// take removes and returns a group of tickets of the lobby size that can
+// start a lobby: the oldest full lobby, or the largest even group of
+// tickets accepting a smaller one. Must hold m.mu.
+func (m *matchmaker) take(size int) ([]*ticket, int) {
+	queue := m.queues[size]
+	if len(queue) >= size {
+		m.queues[size] = slices.Clone(queue[size:])
+		return queue[:size], size
+	}
+
+	var smaller []*ticket
+	for _, t := range queue {
+		if t.smaller {
+			smaller = append(smaller, t)
+		}
+	}
+	n := len(smaller) - len(smaller)%2
+	if n < game.MinPlayers {
+		return nil, 0
+	}
+	smaller = smaller[:n]
+	m.queues[size] = slices.DeleteFunc(queue, func(t *ticket) bool { return slices.Contains(smaller, t) })
+	return smaller, n
+}
+
+// matchGroup is a group of tickets taken from a queue to start a lobby.
+type matchGroup struct {
+	tickets []*ticket
+	size    int
+}
+
+// takeMatches sweeps the queues and takes groups from the queue of the size
+// while it has enough players. Must hold m.mu.
+func (m *matchmaker) takeMatches(size int) []matchGroup {
+	m.sweep()
+	var groups []matchGroup
+	for {
+		tickets, n := m.take(size)
+		if tickets == nil {
+			return groups
+		}
+		for _, t := range tickets {
+			t.matching = true
+		}
+		groups = append(groups, matchGroup{tickets: tickets, size: n})
+	}
+}
+
+// requeue puts the tickets of a failed match back at the head of their
+// queue in their previous order. They are matched again on the next enqueue
+// or accepted smaller lobby.
+func (m *matchmaker) requeue(g matchGroup) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, t := range g.tickets {
+		t.matching = false
+	}
+	size := g.tickets[0].size
+	m.queues[size] = append(slices.Clone(g.tickets), m.queues[size]...)
+}
+
+// startMatches starts a lobby for each group. Must not hold s.mm.mu, adding
+// players waits for the lobby goroutine.
+func (s *Server) startMatches(groups []matchGroup) {
+	for _, g := range groups {
+		s.startMatch(g)
+	}
+}
+
+// startMatch creates a lobby for the group and hands each ticket its session
+// token. If the lobby can't be started the tickets are requeued.
+func (s *Server) startMatch(g matchGroup) {
+	l, tokens, err := s.createMatchLobby(g)
+	if err != nil {
+		slog.Error("start match", "error", err, "size", g.size, "players", len(g.tickets))
+		s.mm.requeue(g)
+		return
+	}
+
+	s.mm.mu.Lock()
+	for i, t := range g.tickets {
+		t.matching = false
+		t.lobbyID = l.ID()
+		t.token = tokens[i]
+		close(t.found)
+	}
+	s.mm.mu.Unlock()
+
+	slog.Info("match found", "lobby", l.ID(), "players", len(g.tickets), "size", g.size)
+}
+
+// createMatchLobby creates a lobby with the group's players and returns their
+// session tokens in ticket order. The lobby is removed if a player can't be
+// added, so the group can start over in another one.
+func (s *Server) createMatchLobby(g matchGroup) (*lobby.Lobby, []string, error) {
+	l, err := lobby.New(s.cards, s.heroes, g.size, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.AddLobby(l); err != nil {
+		l.Close()
+		return nil, nil, err
+	}
+
+	tokens := make([]string, 0, len(g.tickets))
+	for _, t := range g.tickets {
+		token, err := s.addPlayer(l, t.player)
+		if err != nil {
+			s.removeLobby(l)
+			return nil, nil, fmt.Errorf("add player %d: %w", t.player, err)
+		}
+		tokens = append(tokens, token)
+	}
+	return l, tokens, nil
+}
+
+func (s *Server) enqueue(w http.ResponseWriter, r *http.Request) {
+	var req api.EnqueueReq
+	if err := json.UnmarshalRead(r.Body, &req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.MaxPlayers < game.MinPlayers || req.MaxPlayers > game.MaxPlayers || req.MaxPlayers%2 != 0 {
+		http.Error(w, lobby.ErrInvalidPlayerCount.Error(), http.StatusBadRequest)
+		return
+	}
+
+	now := time.Now()
+	t := &ticket{
+		id:         rand.Text(),
+		player:     req.Player,
+		size:       req.MaxPlayers,
+		enqueuedAt: now,
+		polledAt:   now,
+		found:      make(chan struct{}),
+	}
+
+	s.mm.mu.Lock()
+	for _, q := range s.mm.tickets {
+		if q.player == t.player {
+			s.mm.mu.Unlock()
+			http.Error(w, errAlreadyQueued.Error(), http.StatusConflict)
+			return
+		}
+	}
+	s.mm.tickets[t.id] = t
+	s.mm.queues[t.size] = append(s.mm.queues[t.size], t)
+	groups := s.mm.takeMatches(t.size)
+	s.mm.mu.Unlock()
+
+	s.startMatches(groups)
+
+	slog.Info("player queued", "player", t.player, "size", t.size, "ticket", t.id)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.MarshalWrite(w, api.EnqueueResp{Ticket: t.id}); err != nil {
+		slog.Error("encode failed", "error", err)
+	}
+}
+
+// pollTicket waits until the ticket is matched, its queue times out or the
+// poll wait elapses, and returns its state. A found ticket is removed.
+func (s *Server) pollTicket(w http.ResponseWriter, r *http.Request) {
+	s.mm.mu.Lock()
+	t, ok := s.mm.tickets[r.PathValue("ticket")]
+	if ok {
+		t.polledAt = time.Now()
+	}
+	s.mm.mu.Unlock()
+	if !ok {
+		http.Error(w, errTicketNotFound.Error(), http.StatusNotFound)
+		return
+	}
+
+	wait := queuePollWait
+	if untilTimeout := time.Until(t.enqueuedAt.Add(queueTimeout)); untilTimeout > 0 {
+		wait = min(wait, untilTimeout)
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-t.found:
+	case <-timer.C:
+	case <-r.Context().Done():
+		return
+	}
+
+	s.mm.mu.Lock()
+	t.polledAt = time.Now()
+	match := s.mm.match(t)
+	if match.State == api.MatchFound {
+		delete(s.mm.tickets, t.id)
+	}
+	s.mm.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.MarshalWrite(w, match); err != nil {
+		slog.Error("encode failed", "error", err)
+	}
+}
+
+// acceptSmaller lets a timed out ticket start in a smaller lobby with other
+// tickets of the same queue that accepted one.
+func (s *Server) acceptSmaller(w http.ResponseWriter, r *http.Request) {
+	s.mm.mu.Lock()
+	t, ok := s.mm.tickets[r.PathValue("ticket")]
+	if !ok {
+		s.mm.mu.Unlock()
+		http.Error(w, errTicketNotFound.Error(), http.StatusNotFound)
+		return
+	}
+	if time.Since(t.enqueuedAt) < queueTimeout {
+		s.mm.mu.Unlock()
+		http.Error(w, errQueueNotTimed.Error(), http.StatusConflict)
+		return
+	}
+
+	t.smaller = true
+	t.polledAt = time.Now()
+	groups := s.mm.takeMatches(t.size)
+	s.mm.mu.Unlock()
+
+	s.startMatches(groups)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// cancelTicket removes the ticket from the queue. Found or matching tickets
+// can't be cancelled, the player is already in the lobby or being added.
+func (s *Server) cancelTicket(w http.ResponseWriter, r *http.Request) {
+	s.mm.mu.Lock()
+	defer s.mm.mu.Unlock()
+
+	t, ok := s.mm.tickets[r.PathValue("ticket")]
+	if !ok {
+		http.Error(w, errTicketNotFound.Error(), http.StatusNotFound)
+		return
+	}
+	select {
+	case <-t.found:
+		http.Error(w, "match already found", http.StatusConflict)
+		return
+	default:
+	}
+	if t.matching {
+		http.Error(w, "match is starting", http.StatusConflict)
+		return
+	}
+
+	delete(s.mm.tickets, t.id)
+	s.mm.queues[t.size] = slices.DeleteFunc(s.mm.queues[t.size], func(q *ticket) bool { return q == t })
+
+	slog.Info("player left queue", "player", t.player, "ticket", t.id)
+
+	w.WriteHeader(http.StatusNoContent)
+}
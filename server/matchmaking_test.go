@@ -0,0 +1,221 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ysomad/gigabg/api"
+	"github.com/ysomad/gigabg/game"
+)
+
+func newTestTicket(player game.PlayerID, size int, smaller bool) *ticket {
+	now := time.Now()
+	return &ticket{
+		id:         fmt.Sprintf("ticket-%d", player),
+		player:     player,
+		size:       size,
+		enqueuedAt: now,
+		polledAt:   now,
+		smaller:    smaller,
+		found:      make(chan struct{}),
+	}
+}
+
+// queue adds the tickets to the matchmaker in order.
+func (m *matchmaker) queue(tickets ...*ticket) {
+	for _, t := range tickets {
+		m.tickets[t.id] = t
+		m.queues[t.size] = append(m.queues[t.size], t)
+	}
+}
+
+func isFound(t *ticket) bool {
+	select {
+	case <-t.found:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestMatchmaker_take(t *testing.T) {
+	t.Parallel()
+
+	t.Run("full lobby", func(t *testing.T) {
+		t.Parallel()
+		m := newMatchmaker()
+		ts := []*ticket{
+			newTestTicket(1, 4, false), newTestTicket(2, 4, true), newTestTicket(3, 4, false),
+			newTestTicket(4, 4, false), newTestTicket(5, 4, false),
+		}
+		m.queue(ts...)
+
+		got, n := m.take(4)
+		assert.Equal(t, ts[:4], got)
+		assert.Equal(t, 4, n)
+		assert.Equal(t, ts[4:], m.queues[4])
+	})
+
+	t.Run("smaller lobby", func(t *testing.T) {
+		t.Parallel()
+		m := newMatchmaker()
+		ts := []*ticket{
+			newTestTicket(1, 8, true), newTestTicket(2, 8, false), newTestTicket(3, 8, true),
+			newTestTicket(4, 8, true), newTestTicket(5, 8, true),
+		}
+		m.queue(ts...)
+
+		got, n := m.take(8)
+		assert.Equal(t, []*ticket{ts[0], ts[2], ts[3], ts[4]}, got)
+		assert.Equal(t, 4, n)
+		assert.Equal(t, []*ticket{ts[1]}, m.queues[8])
+	})
+
+	t.Run("odd smaller leaves the newest", func(t *testing.T) {
+		t.Parallel()
+		m := newMatchmaker()
+		ts := []*ticket{newTestTicket(1, 8, true), newTestTicket(2, 8, true), newTestTicket(3, 8, true)}
+		m.queue(ts...)
+
+		got, n := m.take(8)
+		assert.Equal(t, ts[:2], got)
+		assert.Equal(t, 2, n)
+		assert.Equal(t, ts[2:], m.queues[8])
+	})
+
+	t.Run("not enough players", func(t *testing.T) {
+		t.Parallel()
+		m := newMatchmaker()
+		ts := []*ticket{newTestTicket(1, 8, true), newTestTicket(2, 8, false), newTestTicket(3, 8, false)}
+		m.queue(ts...)
+
+		got, n := m.take(8)
+		assert.Nil(t, got)
+		assert.Zero(t, n)
+		assert.Equal(t, ts, m.queues[8])
+	})
+}
+
+func TestMatchmaker_sweep(t *testing.T) {
+	t.Parallel()
+
+	m := newMatchmaker()
+	fresh := newTestTicket(1, 4, false)
+	stale := newTestTicket(2, 4, false)
+	stale.polledAt = time.Now().Add(-ticketTTL)
+	matching := newTestTicket(3, 4, false)
+	matching.polledAt = time.Now().Add(-ticketTTL)
+	matching.matching = true
+	m.queue(fresh, stale)
+	m.tickets[matching.id] = matching
+
+	m.sweep()
+
+	assert.Equal(t, map[string]*ticket{fresh.id: fresh, matching.id: matching}, m.tickets)
+	assert.Equal(t, []*ticket{fresh}, m.queues[4])
+}
+
+func TestServer_startMatch(t *testing.T) {
+	t.Parallel()
+
+	s, _ := newTestServer(t)
+	g := matchGroup{tickets: []*ticket{newTestTicket(1, 2, false), newTestTicket(2, 2, false)}, size: 2}
+	for _, tk := range g.tickets {
+		tk.matching = true
+		s.mm.tickets[tk.id] = tk
+	}
+
+	s.startMatch(g)
+
+	for _, tk := range g.tickets {
+		require.True(t, isFound(tk))
+		assert.False(t, tk.matching)
+		sess, err := s.signer.verify(tk.token)
+		require.NoError(t, err)
+		assert.Equal(t, tk.player, sess.Player)
+		l, err := s.store.Lobby(tk.lobbyID)
+		require.NoError(t, err)
+		assert.True(t, sess.validFor(l))
+	}
+	assert.Empty(t, s.mm.queues[2])
+}
+
+func TestServer_startMatch_Failed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		players []game.PlayerID
+		size    int
+	}{
+		{"lobby not created", []game.PlayerID{1, 2, 3}, 3},
+		{"player not added", []game.PlayerID{1, 1}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, _ := newTestServer(t)
+			queued := newTestTicket(10, 4, false)
+			s.mm.queue(queued)
+			g := matchGroup{size: tt.size}
+			for i, p := range tt.players {
+				tk := newTestTicket(p, 4, true)
+				tk.id = fmt.Sprintf("ticket-%d", i)
+				tk.matching = true
+				s.mm.tickets[tk.id] = tk
+				g.tickets = append(g.tickets, tk)
+			}
+
+			s.startMatch(g)
+
+			assert.Empty(t, s.store.Lobbies(), "failed lobby is removed")
+			assert.Equal(t, append(g.tickets, queued), s.mm.queues[4], "requeued at the head")
+			for _, tk := range g.tickets {
+				assert.False(t, isFound(tk))
+				assert.False(t, tk.matching)
+			}
+		})
+	}
+}
+
+func TestServer_cancelTicket(t *testing.T) {
+	t.Parallel()
+
+	s, ts := newTestServer(t)
+
+	cancel := func(ticket string) int {
+		req, err := http.NewRequest(http.MethodDelete, ts.URL+"/matchmaking/"+ticket, nil)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	enqueue := func(player game.PlayerID, size int) string {
+		var resp api.EnqueueResp
+		require.Equal(t, http.StatusOK, postJSON(t, ts, "/matchmaking", api.EnqueueReq{Player: player, MaxPlayers: size}, &resp))
+		return resp.Ticket
+	}
+
+	queued := enqueue(1, 4)
+	assert.Equal(t, http.StatusNoContent, cancel(queued))
+	assert.Equal(t, http.StatusNotFound, cancel(queued))
+
+	// The second player fills the lobby, both tickets are found.
+	found := enqueue(2, 2)
+	enqueue(3, 2)
+	assert.Equal(t, http.StatusConflict, cancel(found))
+
+	matching := newTestTicket(4, 4, false)
+	matching.matching = true
+	s.mm.mu.Lock()
+	s.mm.tickets[matching.id] = matching
+	s.mm.mu.Unlock()
+	assert.Equal(t, http.StatusConflict, cancel(matching.id))
+}
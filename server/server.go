@@ -24,8 +24,17 @@ type Server struct {
 	mux     *http.ServeMux
 	store   *lobby.MemoryStore
 	cards   game.CardCatalog
+	heroes  game.HeroCatalog
+	signer  sessionSigner
+	mm      *matchmaker
 	clients map[string][]*ClientConn // lobbyID -> clients
 	mu      sync.RWMutex
+
+	spectators     map[string][]*spectator // lobbyID -> spectators
+	spectatorDelay time.Duration
+	specMu         sync.RWMutex
+
+	combatDumpDir string
 }
 
 type ClientConn struct {
@@ -35,24 +44,39 @@ type ClientConn struct {
 	send    chan []byte
 }
 
-func New(store *lobby.MemoryStore, cards game.CardCatalog) *Server {
+// New creates a server. sessionKey signs the session tokens handed out to
+// players joining lobbies.
+func New(store *lobby.MemoryStore, cards game.CardCatalog, heroes game.HeroCatalog, sessionKey []byte) *Server {
 	s := &Server{
 		store:   store,
 		cards:   cards,
+		heroes:  heroes,
+		signer:  sessionSigner{key: sessionKey},
+		mm:      newMatchmaker(),
 		clients: make(map[string][]*ClientConn),
 		mux:     http.NewServeMux(),
+
+		spectators:     make(map[string][]*spectator),
+		spectatorDelay: defaultSpectatorDelay,
 	}
 
+	s.mux.HandleFunc("GET /lobbies", s.listLobbies)
 	s.mux.HandleFunc("POST /lobbies", s.createLobby)
+	s.mux.HandleFunc("GET /lobbies/{id}", s.getLobby)
+	s.mux.HandleFunc("POST /lobbies/{id}/players", s.joinLobby)
+	s.mux.HandleFunc("POST /lobbies/{id}/spectators", s.inviteSpectator)
+	s.mux.HandleFunc("POST /matchmaking", s.enqueue)
+	s.mux.HandleFunc("GET /matchmaking/{ticket}", s.pollTicket)
+	s.mux.HandleFunc("POST /matchmaking/{ticket}/smaller", s.acceptSmaller)
+	s.mux.HandleFunc("DELETE /matchmaking/{ticket}", s.cancelTicket)
 	s.mux.HandleFunc("/ws", s.handleWS)
 
-	go s.gameLoop()
 	return s
 }
 
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, DELETE, OPTIONS")
 	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
 
 	if r.Method == http.MethodOptions {
@@ -70,93 +94,225 @@ func (s *Server) createLobby(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	l, err := lobby.New(s.cards, req.MaxPlayers)
+	l, err := lobby.New(s.cards, s.heroes, req.MaxPlayers, req.Tribes)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	if err := s.store.CreateLobby(l); err != nil {
+	if err := s.AddLobby(l); err != nil {
+		l.Close()
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	slog.Info("lobby created", "lobby", l.ID(), "max_players", req.MaxPlayers)
+	slog.Info("lobby created", "lobby", l.ID(), "max_players", req.MaxPlayers, "tribes", fmt.Sprint(l.Tribes().List()))
+
+	token, err := s.addPlayer(l, req.Player)
+	if err != nil {
+		s.removeLobby(l)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 
 	w.Header().Set("Content-Type", "application/json")
-	if err := json.MarshalWrite(w, api.CreateLobbyResp{LobbyID: l.ID()}); err != nil {
+	if err := json.MarshalWrite(w, api.CreateLobbyResp{LobbyID: l.ID(), Token: token}); err != nil {
 		slog.Error("encode failed", "error", err)
 	}
 }
 
-// gameLoop runs periodically to advance phases in all lobbies.
-func (s *Server) gameLoop() {
-	ticker := time.NewTicker(1 * time.Second)
-	defer ticker.Stop()
+func (s *Server) listLobbies(w http.ResponseWriter, _ *http.Request) {
+	resp := api.ListLobbiesResp{Lobbies: []api.LobbySummary{}}
+	for _, l := range s.store.Lobbies() {
+		err := l.Do(func(l *lobby.Lobby) error {
+			if l.State() != lobby.StateWaiting {
+				return nil
+			}
+			resp.Lobbies = append(resp.Lobbies, api.LobbySummary{
+				ID:         l.ID(),
+				Players:    l.PlayerCount(),
+				MaxPlayers: l.MaxPlayers(),
+				Age:        int(time.Since(l.CreatedAt()).Seconds()),
+			})
+			return nil
+		})
+		if err != nil && !errors.Is(err, lobby.ErrLobbyClosed) {
+			slog.Error("list lobby", "error", err, "lobby", l.ID())
+		}
+	}
 
-	for range ticker.C {
-		s.mu.RLock()
-		lobbyIDs := make([]string, 0, len(s.clients))
-		for id := range s.clients {
-			lobbyIDs = append(lobbyIDs, id)
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.MarshalWrite(w, resp); err != nil {
+		slog.Error("encode failed", "error", err)
+	}
+}
+
+func (s *Server) getLobby(w http.ResponseWriter, r *http.Request) {
+	l, err := s.store.Lobby(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	var resp api.Lobby
+	err = l.Do(func(l *lobby.Lobby) error {
+		resp = api.Lobby{
+			ID:      l.ID(),
+			State:   l.State().String(),
+			Turn:    l.Turn(),
+			Phase:   l.Phase(),
+			Players: make([]api.LobbyPlayer, 0, l.PlayerCount()),
+		}
+		for _, p := range l.Players() {
+			resp.Players = append(resp.Players, api.LobbyPlayer{ID: p.ID(), HP: p.HP(), Armor: p.Armor()})
 		}
-		s.mu.RUnlock()
+		return nil
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
 
-		for _, lobbyID := range lobbyIDs {
-			l, err := s.store.Lobby(lobbyID)
-			if err != nil {
-				continue
-			}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.MarshalWrite(w, resp); err != nil {
+		slog.Error("encode failed", "error", err)
+	}
+}
 
-			if l.AdvancePhase() {
-				slog.Info("phase changed",
-					"lobby", lobbyID,
-					"turn", l.Turn(),
-					"phase", l.Phase().String(),
-				)
-				s.sendCombatLogs(lobbyID, l)
-				s.broadcastState(lobbyID, l)
-
-				if l.State() == lobby.StateFinished {
-					if err := s.store.DeleteLobby(lobbyID); err != nil {
-						slog.Error("delete lobby", "error", err, "lobby", lobbyID)
-					}
-
-					s.mu.Lock()
-					delete(s.clients, lobbyID)
-					s.mu.Unlock()
-
-					slog.Info("game finished, lobby removed", "lobby", lobbyID)
-				}
-			}
+func (s *Server) joinLobby(w http.ResponseWriter, r *http.Request) {
+	var req api.JoinLobbyReq
+	if err := json.UnmarshalRead(r.Body, &req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	l, err := s.store.Lobby(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	token, err := s.addPlayer(l, req.Player)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.MarshalWrite(w, api.JoinLobbyResp{Token: token}); err != nil {
+		slog.Error("encode failed", "error", err)
+	}
+}
+
+// addPlayer adds the player to the lobby and returns their session token.
+// Only the player holding the token may connect as that player.
+func (s *Server) addPlayer(l *lobby.Lobby, player game.PlayerID) (string, error) {
+	lobbyID := l.ID()
+	err := l.Do(func(l *lobby.Lobby) error {
+		if err := l.AddPlayer(player); err != nil {
+			return err
 		}
+
+		slog.Info("player joined",
+			"player", player,
+			"lobby", lobbyID,
+			"lobby_players", l.PlayerCount(),
+			"lobby_max_players", l.MaxPlayers(),
+		)
+
+		if l.State() == lobby.StatePlaying {
+			slog.Info("game started",
+				"lobby", lobbyID,
+				"turn", l.Turn(),
+				"phase", l.Phase().String(),
+			)
+		}
+
+		s.broadcastState(lobbyID, l)
+		return nil
+	})
+	if err != nil {
+		return "", err
 	}
+	return s.signer.issue(l, player)
 }
 
-func (s *Server) handleWS(w http.ResponseWriter, r *http.Request) {
-	lobbyID := r.URL.Query().Get("lobby")
-	playerStr := r.URL.Query().Get("player")
+// AddLobby stores the lobby and notifies its clients about phase changes.
+// The store closes the lobby once it's deleted; if it can't be stored, the
+// caller must close it.
+func (s *Server) AddLobby(l *lobby.Lobby) error {
+	l.SetPhaseHandler(s.phaseChanged)
+	return s.store.CreateLobby(l)
+}
 
-	slog.Info("ws connect", "player", playerStr, "lobby", lobbyID, "remote", r.RemoteAddr)
+// removeLobby deletes a stored lobby that failed to start. Deleting closes it.
+func (s *Server) removeLobby(l *lobby.Lobby) {
+	if err := s.store.DeleteLobby(l.ID()); err != nil {
+		slog.Error("delete lobby", "error", err, "lobby", l.ID())
+	}
+}
+
+// phaseChanged sends the new phase to the lobby's clients and removes the
+// lobby once the game is finished. Runs on the lobby goroutine.
+func (s *Server) phaseChanged(l *lobby.Lobby) {
+	lobbyID := l.ID()
+	slog.Info("phase changed",
+		"lobby", lobbyID,
+		"turn", l.Turn(),
+		"phase", l.Phase().String(),
+	)
+	if s.combatDumpDir != "" && l.Phase() == game.PhaseCombat {
+		s.dumpCombats(l)
+	}
+	s.sendCombatLogs(lobbyID, l)
+	s.broadcastState(lobbyID, l)
+
+	if l.State() != lobby.StateFinished {
+		return
+	}
+
+	if err := s.store.DeleteLobby(lobbyID); err != nil {
+		slog.Error("delete lobby", "error", err, "lobby", lobbyID)
+	}
+
+	s.mu.Lock()
+	delete(s.clients, lobbyID)
+	s.mu.Unlock()
+
+	s.specMu.Lock()
+	delete(s.spectators, lobbyID)
+	s.specMu.Unlock()
+
+	slog.Info("game finished, lobby removed", "lobby", lobbyID)
+}
 
-	if lobbyID == "" {
-		slog.Info("ws rejected, missing lobby param", "remote", r.RemoteAddr)
-		http.Error(w, "lobby query param required", http.StatusBadRequest)
+func (s *Server) handleWS(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Has("spectate") {
+		s.handleSpectate(w, r)
 		return
 	}
 
-	if playerStr == "" {
-		slog.Info("ws rejected, missing player param", "remote", r.RemoteAddr)
-		http.Error(w, "player query param required", http.StatusBadRequest)
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		slog.Info("ws rejected, missing token param", "remote", r.RemoteAddr)
+		http.Error(w, "token query param required", http.StatusUnauthorized)
 		return
 	}
 
-	player, err := game.ParsePlayerID(playerStr)
+	sess, err := s.signer.verify(token)
 	if err != nil {
-		slog.Info("ws rejected, invalid player param", "remote", r.RemoteAddr)
-		http.Error(w, "invalid player query param", http.StatusBadRequest)
+		slog.Info("ws rejected, invalid token", "remote", r.RemoteAddr, "error", err)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if sess.Spectator {
+		slog.Info("ws rejected, spectator token", "remote", r.RemoteAddr)
+		http.Error(w, errSpectatorToken.Error(), http.StatusUnauthorized)
 		return
 	}
+	lobbyID, player := sess.Lobby, sess.Player
+
+	slog.Info("ws connect", "player", player, "lobby", lobbyID, "remote", r.RemoteAddr)
 
 	l, err := s.store.Lobby(lobbyID)
 	if err != nil {
@@ -164,6 +320,11 @@ func (s *Server) handleWS(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusNotFound)
 		return
 	}
+	if !sess.validFor(l) {
+		slog.Info("ws rejected, stale token", "lobby", lobbyID, "player", player)
+		http.Error(w, errStaleToken.Error(), http.StatusUnauthorized)
+		return
+	}
 
 	conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{
 		OriginPatterns: []string{"*"},
@@ -174,43 +335,31 @@ func (s *Server) handleWS(w http.ResponseWriter, r *http.Request) {
 	}
 
 	client := &ClientConn{
-		conn: conn,
-		send: make(chan []byte, 256),
+		player:  player,
+		lobbyID: lobbyID,
+		conn:    conn,
+		send:    make(chan []byte, 256),
 	}
 
-	// Join lobby on connect.
-	s.mu.Lock()
+	// Players join over HTTP, the connection only attaches to the player.
+	// A reconnecting player replaces their previous connection.
+	s.addClient(client)
 
-	if err := l.AddPlayer(player); err != nil {
-		s.mu.Unlock()
+	err = l.Do(func(l *lobby.Lobby) error {
+		if l.Player(player) == nil {
+			return lobby.ErrNotAllowed
+		}
+		s.sendConnectState(client, l)
+		return nil
+	})
+	if err != nil {
+		s.removeClient(client)
 		if cerr := conn.Close(websocket.StatusPolicyViolation, err.Error()); cerr != nil {
 			slog.Error("close rejected player conn", "error", cerr, "player", player)
 		}
 		return
 	}
 
-	client.player = player
-	client.lobbyID = lobbyID
-	s.clients[lobbyID] = append(s.clients[lobbyID], client)
-	s.mu.Unlock()
-
-	slog.Info("player joined",
-		"player", player,
-		"lobby", lobbyID,
-		"lobby_players", l.PlayerCount(),
-		"lobby_max_players", l.MaxPlayers(),
-	)
-
-	if l.State() == lobby.StatePlaying {
-		slog.Info("game started",
-			"lobby", lobbyID,
-			"turn", l.Turn(),
-			"phase", l.Phase().String(),
-		)
-	}
-
-	s.broadcastState(lobbyID, l)
-
 	go s.writePump(r.Context(), client)
 	s.readPump(r.Context(), client)
 }
@@ -268,6 +417,16 @@ func decodePayload[T any](msg *api.ClientMessage) (T, error) {
 	return v, nil
 }
 
+// targetIndex returns the board index of an optional action target, or -1
+// when the client sent none. Targeted spells and hero powers reject -1, a
+// Battlecry without a target does nothing.
+func targetIndex(idx *int) int {
+	if idx == nil {
+		return -1
+	}
+	return *idx
+}
+
 func (s *Server) handleMessage(ctx context.Context, client *ClientConn, msg *api.ClientMessage) {
 	switch msg.Action {
 	case api.ActionBuyCard:
@@ -298,7 +457,7 @@ func (s *Server) handleMessage(ctx context.Context, client *ClientConn, msg *api
 			if err != nil {
 				return err
 			}
-			return p.PlayMinion(payload.HandIndex, payload.BoardPosition, l.Pool())
+			return p.PlayMinion(payload.HandIndex, payload.BoardPosition, targetIndex(payload.TargetIndex), l.Pool())
 		})
 
 	case api.ActionRemoveMinion:
@@ -324,7 +483,7 @@ func (s *Server) handleMessage(ctx context.Context, client *ClientConn, msg *api
 			if err != nil {
 				return err
 			}
-			return p.PlaySpell(payload.HandIndex, l.Pool())
+			return p.PlaySpell(payload.HandIndex, targetIndex(payload.TargetIndex), l.Pool())
 		})
 
 	case api.ActionDiscoverPick:
@@ -343,11 +502,41 @@ func (s *Server) handleMessage(ctx context.Context, client *ClientConn, msg *api
 		})
 
 	case api.ActionReorderCards:
-		if err := s.handleReorder(client, msg); err != nil {
+		if err := s.doLobby(client, func(l *lobby.Lobby) error { return s.handleReorder(client, l, msg) }); err != nil {
+			s.sendError(client, err.Error())
+		}
+
+	case api.ActionPickHero:
+		if err := s.doLobby(client, func(l *lobby.Lobby) error { return s.handlePickHero(client, l, msg) }); err != nil {
 			s.sendError(client, err.Error())
 		}
 
+	case api.ActionUseHeroPower:
+		s.handleAction(ctx, client, msg.Action, func(l *lobby.Lobby, p *game.Player) error {
+			payload, err := decodePayload[api.UseHeroPower](msg)
+			if err != nil {
+				return err
+			}
+			return p.UseHeroPower(targetIndex(payload.TargetIndex), l.Pool())
+		})
+
+	case api.ActionEndTurn:
+		if err := s.doLobby(client, func(l *lobby.Lobby) error { return s.handleEndTurn(client, l) }); err != nil {
+			s.sendError(client, err.Error())
+		}
+	}
+}
+
+// doLobby runs fn on the goroutine of the client's lobby.
+func (s *Server) doLobby(client *ClientConn, fn func(l *lobby.Lobby) error) error {
+	if client.lobbyID == "" {
+		return errors.New("not in lobby")
+	}
+	l, err := s.store.Lobby(client.lobbyID)
+	if err != nil {
+		return err
 	}
+	return l.Do(fn)
 }
 
 func (s *Server) handleAction(
@@ -356,25 +545,30 @@ func (s *Server) handleAction(
 	action api.Action,
 	fn func(l *lobby.Lobby, p *game.Player) error,
 ) {
-	if client.lobbyID == "" {
-		s.sendError(client, "not in lobby")
-		return
-	}
-
-	l, err := s.store.Lobby(client.lobbyID)
+	err := s.doLobby(client, func(l *lobby.Lobby) error {
+		return s.applyAction(ctx, client, l, action, fn)
+	})
 	if err != nil {
 		s.sendError(client, err.Error())
-		return
 	}
+}
 
+// applyAction runs a recruit phase player action on the lobby goroutine and
+// sends the updated state to the client.
+func (s *Server) applyAction(
+	ctx context.Context,
+	client *ClientConn,
+	l *lobby.Lobby,
+	action api.Action,
+	fn func(l *lobby.Lobby, p *game.Player) error,
+) error {
 	if l.State() != lobby.StatePlaying || l.Phase() != game.PhaseRecruit {
-		return
+		return nil
 	}
 
 	p := l.Player(client.player)
 	if p == nil {
-		s.sendError(client, "player not found")
-		return
+		return errors.New("player not found")
 	}
 
 	beforeGold := p.Gold()
@@ -385,9 +579,9 @@ func (s *Server) handleAction(
 	beforeShop := len(p.Shop().Cards())
 
 	if err := fn(l, p); err != nil {
-		s.sendError(client, err.Error())
-		return
+		return err
 	}
+	s.unready(client, l)
 
 	attrs := []slog.Attr{
 		slog.Any("player", client.player),
@@ -414,6 +608,7 @@ func (s *Server) handleAction(
 	slog.LogAttrs(ctx, slog.LevelInfo, action.String(), attrs...)
 
 	s.sendPlayerState(client, l, p)
+	return nil
 }
 
 func (s *Server) sendCombatLogs(lobbyID string, l *lobby.Lobby) {
@@ -432,6 +627,10 @@ func (s *Server) sendCombatLogs(lobbyID string, l *lobby.Lobby) {
 			if c.player != anim.Player1 && c.player != anim.Player2 {
 				continue
 			}
+			// Ghost owners are eliminated and don't watch their ghost's fights.
+			if _, ok := l.CombatPairing(c.player); !ok {
+				continue
+			}
 
 			events, err := api.NewCombatEvents(anim.Events)
 			if err != nil {
@@ -442,29 +641,73 @@ func (s *Server) sendCombatLogs(lobbyID string, l *lobby.Lobby) {
 			s.sendMessage(c, &api.ServerMessage{CombatEvents: events})
 		}
 	}
+
+	s.sendSpectatorCombatLogs(lobbyID, l)
+}
+
+// connectState returns the messages bringing a new connection following the
+// player up to date: its combat log when the combat phase is still playing,
+// then the full state.
+func (s *Server) connectState(l *lobby.Lobby, p *game.Player) []*api.ServerMessage {
+	var msgs []*api.ServerMessage
+	if l.Phase() == game.PhaseCombat {
+		if log, ok := l.CombatLog(p.ID()); ok {
+			events, err := api.NewCombatEvents(log.Events)
+			if err != nil {
+				slog.Error("failed to marshal combat events", "player", p.ID(), "err", err)
+			} else {
+				msgs = append(msgs, &api.ServerMessage{CombatEvents: events})
+			}
+		}
+	}
+	return append(msgs, &api.ServerMessage{State: s.playerState(l, p)})
+}
+
+// sendConnectState sends the full state to a newly connected client, preceded
+// by its combat log when the combat phase is still playing.
+func (s *Server) sendConnectState(client *ClientConn, l *lobby.Lobby) {
+	p := l.Player(client.player)
+	if p == nil {
+		return
+	}
+	for _, msg := range s.connectState(l, p) {
+		s.sendMessage(client, msg)
+	}
 }
 
 func (s *Server) broadcastState(lobbyID string, l *lobby.Lobby) {
 	s.mu.RLock()
-	defer s.mu.RUnlock()
-
 	for _, c := range s.clients[lobbyID] {
 		p := l.Player(c.player)
 		if p == nil {
 			continue
 		}
-		s.sendPlayerState(c, l, p)
+		s.sendMessage(c, &api.ServerMessage{State: s.playerState(l, p)})
 	}
+	s.mu.RUnlock()
+
+	s.broadcastSpectatorState(lobbyID, l)
 }
 
+// sendPlayerState sends the player's state to its client and to the
+// spectators following the player.
 func (s *Server) sendPlayerState(client *ClientConn, l *lobby.Lobby, p *game.Player) {
+	msg := &api.ServerMessage{State: s.playerState(l, p)}
+	s.sendMessage(client, msg)
+	s.sendSpectators(client.lobbyID, p.ID(), msg)
+}
+
+// playerState returns the lobby as seen by the player.
+func (s *Server) playerState(l *lobby.Lobby, p *game.Player) *api.GameState {
+	player := p.ID()
 	state := &api.GameState{
 		Player: api.NewPlayer(p),
 		Opponents: api.NewOpponents(
 			l.Players(),
-			client.player,
+			player,
 			api.NewAllCombatResults(l.AllCombatResults()),
 			l.TopTribes(),
+			l.ReadyPlayers(),
 		),
 		Turn:          l.Turn(),
 		Phase:         l.Phase(),
@@ -473,19 +716,27 @@ func (s *Server) sendPlayerState(client *ClientConn, l *lobby.Lobby, p *game.Pla
 		IsShopFrozen:  p.Shop().IsFrozen(),
 		Hand:          api.NewCards(p.Hand()),
 		Board:         api.NewCardsFromMinions(p.Board().Minions()),
-		CombatResults: api.NewCombatResults(l.CombatResults(client.player)),
+		CombatResults: api.NewCombatResults(l.CombatResults(player)),
+		Tribes:        l.Tribes(),
 	}
 
 	if p.HasDiscovers() {
 		state.Discovers = api.NewCards(p.Discovers())
 	}
 
+	if l.Phase() == game.PhaseHeroSelect {
+		state.HeroOffers = api.NewHeroes(l.HeroOffers(player))
+	}
+
 	switch l.Phase() {
 	case game.PhaseRecruit:
-		state.Opponent = l.NextOpponent(client.player)
+		state.Opponent = l.NextOpponent(player)
+		state.IsGhost = l.FightsGhost(player)
+		state.Player.Ready = l.Ready(player)
 	case game.PhaseCombat, game.PhaseFinished:
-		if pair, ok := l.CombatPairing(client.player); ok {
+		if pair, ok := l.CombatPairing(player); ok {
 			state.Opponent = pair.Opponent
+			state.IsGhost = l.FightsGhost(player)
 			state.CombatBoard = api.CombatCards(pair.PlayerBoard)
 			state.OpponentBoard = api.CombatCards(pair.OpponentBoard)
 		}
@@ -495,14 +746,10 @@ func (s *Server) sendPlayerState(client *ClientConn, l *lobby.Lobby, p *game.Pla
 		state.GameResult = api.NewGameResult(l.GameResult())
 	}
 
-	s.sendMessage(client, &api.ServerMessage{State: state})
+	return state
 }
 
-func (s *Server) handleReorder(client *ClientConn, msg *api.ClientMessage) error {
-	l, err := s.store.Lobby(client.lobbyID)
-	if err != nil {
-		return fmt.Errorf("lobby: %w", err)
-	}
+func (s *Server) handleReorder(client *ClientConn, l *lobby.Lobby, msg *api.ClientMessage) error {
 	p := l.Player(client.player)
 	if p == nil {
 		return fmt.Errorf("player not found")
@@ -517,6 +764,56 @@ func (s *Server) handleReorder(client *ClientConn, msg *api.ClientMessage) error
 	if err := p.ReorderShop(payload.ShopOrder); err != nil {
 		return fmt.Errorf("shop: %w", err)
 	}
+	s.unready(client, l)
+	return nil
+}
+
+// handleEndTurn marks the player ready. The lobby starts combat once all
+// living players are ready.
+func (s *Server) handleEndTurn(client *ClientConn, l *lobby.Lobby) error {
+	p := l.Player(client.player)
+	if p == nil {
+		return fmt.Errorf("player not found")
+	}
+	if err := l.SetReady(client.player, true); err != nil {
+		return err
+	}
+
+	slog.Info("turn ended", "player", client.player, "lobby", client.lobbyID)
+
+	s.sendOpponentUpdate(client.lobbyID, api.OpponentUpdate{Player: client.player, Ready: true})
+	s.sendPlayerState(client, l, p)
+	return nil
+}
+
+// unready takes back the player's ended turn after any further action.
+func (s *Server) unready(client *ClientConn, l *lobby.Lobby) {
+	if !l.Ready(client.player) {
+		return
+	}
+	if err := l.SetReady(client.player, false); err != nil {
+		slog.Error("unready player", "error", err, "player", client.player, "lobby", client.lobbyID)
+		return
+	}
+	s.sendOpponentUpdate(client.lobbyID, api.OpponentUpdate{Player: client.player})
+}
+
+func (s *Server) handlePickHero(client *ClientConn, l *lobby.Lobby, msg *api.ClientMessage) error {
+	p := l.Player(client.player)
+	if p == nil {
+		return fmt.Errorf("player not found")
+	}
+	payload, err := decodePayload[api.PickHero](msg)
+	if err != nil {
+		return fmt.Errorf("decode: %w", err)
+	}
+	if err := l.PickHero(client.player, payload.Index); err != nil {
+		return err
+	}
+
+	slog.Info("hero picked", "player", client.player, "lobby", client.lobbyID, "hero", p.Hero().ID())
+
+	s.sendPlayerState(client, l, p)
 	return nil
 }
 
@@ -525,27 +822,35 @@ func (s *Server) handleUpgradeShop(ctx context.Context, client *ClientConn, acti
 		if err := p.UpgradeShop(); err != nil {
 			return err
 		}
-		s.sendOpponentUpdate(client.lobbyID, client.player, p.Shop().Tier())
+		s.sendOpponentUpdate(client.lobbyID, api.OpponentUpdate{
+			Player:   client.player,
+			ShopTier: p.Shop().Tier(),
+		})
 		return nil
 	})
 }
 
-func (s *Server) sendOpponentUpdate(lobbyID string, player game.PlayerID, tier game.Tier) {
+func (s *Server) sendOpponentUpdate(lobbyID string, update api.OpponentUpdate) {
 	s.mu.RLock()
-	defer s.mu.RUnlock()
 
-	msg := &api.ServerMessage{
-		OpponentUpdate: &api.OpponentUpdate{
-			Player:   player,
-			ShopTier: tier,
-		},
-	}
+	msg := &api.ServerMessage{OpponentUpdate: &update}
 	for _, c := range s.clients[lobbyID] {
-		if c.player == player {
+		if c.player == update.Player {
 			continue
 		}
 		s.sendMessage(c, msg)
 	}
+	s.mu.RUnlock()
+
+	s.specMu.RLock()
+	defer s.specMu.RUnlock()
+
+	for _, sp := range s.spectators[lobbyID] {
+		if sp.following == update.Player {
+			continue
+		}
+		s.sendSpectator(sp, msg)
+	}
 }
 
 func (s *Server) sendError(client *ClientConn, msg string) {
@@ -569,6 +874,38 @@ func (s *Server) sendMessage(client *ClientConn, msg *api.ServerMessage) {
 	}
 }
 
+// addClient registers the client in its lobby. An older connection of the
+// same player is replaced and closed.
+func (s *Server) addClient(client *ClientConn) {
+	s.mu.Lock()
+	var old *ClientConn
+	clients := s.clients[client.lobbyID]
+	for i, c := range clients {
+		if c.player == client.player {
+			old = c
+			clients[i] = client
+			break
+		}
+	}
+	if old == nil {
+		s.clients[client.lobbyID] = append(clients, client)
+	}
+	s.mu.Unlock()
+
+	if old == nil {
+		return
+	}
+
+	slog.Info("connection replaced", "player", client.player, "lobby", client.lobbyID)
+
+	// Closing waits for the close handshake, which a dropped peer never answers.
+	go func() {
+		if err := old.conn.Close(websocket.StatusNormalClosure, "replaced by a new connection"); err != nil {
+			slog.Debug("close replaced conn", "error", err, "player", old.player)
+		}
+	}()
+}
+
 func (s *Server) removeClient(client *ClientConn) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -0,0 +1,185 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	json "encoding/json/v2"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ysomad/gigabg/api"
+	"github.com/ysomad/gigabg/game"
+	"github.com/ysomad/gigabg/game/catalog"
+	"github.com/ysomad/gigabg/lobby"
+)
+
+func newTestServer(t *testing.T) (*Server, *httptest.Server) {
+	t.Helper()
+	cards, err := catalog.New()
+	require.NoError(t, err)
+	s := New(lobby.NewMemoryStore(), cards, cards, []byte("test key"))
+	ts := httptest.NewServer(s)
+	t.Cleanup(ts.Close)
+	return s, ts
+}
+
+// postJSON posts req to the test server and decodes the response into resp.
+// Returns the response status.
+func postJSON(t *testing.T, ts *httptest.Server, path string, req, resp any) int {
+	t.Helper()
+	body, err := json.Marshal(req)
+	require.NoError(t, err)
+	r, err := http.Post(ts.URL+path, "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	defer r.Body.Close()
+	if r.StatusCode == http.StatusOK && resp != nil {
+		require.NoError(t, json.UnmarshalRead(r.Body, resp))
+	}
+	return r.StatusCode
+}
+
+// createTestLobby creates a full lobby and returns its ID and the session
+// tokens of players 1 to size.
+func createTestLobby(t *testing.T, ts *httptest.Server, size int) (string, []string) {
+	t.Helper()
+	var created api.CreateLobbyResp
+	require.Equal(t, http.StatusOK, postJSON(t, ts, "/lobbies", api.CreateLobbyReq{MaxPlayers: size, Player: 1}, &created))
+
+	tokens := []string{created.Token}
+	for i := 2; i <= size; i++ {
+		var joined api.JoinLobbyResp
+		req := api.JoinLobbyReq{Player: game.PlayerID(i)}
+		require.Equal(t, http.StatusOK, postJSON(t, ts, "/lobbies/"+created.LobbyID+"/players", req, &joined))
+		tokens = append(tokens, joined.Token)
+	}
+	return created.LobbyID, tokens
+}
+
+func dialWS(t *testing.T, ts *httptest.Server, query string) *websocket.Conn {
+	t.Helper()
+	conn, _, err := websocket.Dial(context.Background(), "ws"+strings.TrimPrefix(ts.URL, "http")+"/ws?"+query, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.CloseNow() }) //nolint:errcheck // best-effort cleanup
+	return conn
+}
+
+func sendAction(conn *websocket.Conn, action api.Action, payload any) error {
+	msg := api.ClientMessage{Action: action}
+	if payload != nil {
+		raw, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		msg.Payload = raw
+	}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return conn.Write(context.Background(), websocket.MessageBinary, data)
+}
+
+// lobbyPhase returns the lobby's turn and phase.
+func lobbyPhase(t *testing.T, l *lobby.Lobby) (int, game.Phase) {
+	t.Helper()
+	var (
+		turn  int
+		phase game.Phase
+	)
+	require.NoError(t, l.Do(func(l *lobby.Lobby) error {
+		turn, phase = l.Turn(), l.Phase()
+		return nil
+	}))
+	return turn, phase
+}
+
+func TestServer_ConcurrentClients(t *testing.T) {
+	t.Parallel()
+
+	s, ts := newTestServer(t)
+	lobbyID, tokens := createTestLobby(t, ts, game.MaxPlayers)
+	l, err := s.store.Lobby(lobbyID)
+	require.NoError(t, err)
+
+	actions := []struct {
+		action  api.Action
+		payload any
+	}{
+		{api.ActionBuyCard, api.BuyCard{ShopIndex: 0}},
+		{api.ActionPlaceMinion, api.PlaceMinion{HandIndex: 0, BoardPosition: 0}},
+		{api.ActionReorderCards, api.ReorderCards{ShopOrder: []int{1, 0}}},
+		{api.ActionRefreshShop, nil},
+		{api.ActionUpgradeShop, nil},
+		{api.ActionSellMinion, api.SellMinion{BoardIndex: 0}},
+		{api.ActionFreezeShop, nil},
+		{api.ActionEndTurn, nil},
+	}
+
+	conns := make([]*websocket.Conn, len(tokens))
+	var wg sync.WaitGroup
+	for i, token := range tokens {
+		conn := dialWS(t, ts, "token="+token)
+		conns[i] = conn
+		// Drain server messages so the connection keeps reading control frames.
+		go func() {
+			for {
+				if _, _, err := conn.Read(context.Background()); err != nil {
+					return
+				}
+			}
+		}()
+		wg.Go(func() {
+			assert.NoError(t, sendAction(conn, api.ActionPickHero, api.PickHero{Index: 0}))
+			for j := range 200 {
+				a := actions[j%len(actions)]
+				assert.NoError(t, sendAction(conn, a.action, a.payload))
+			}
+		})
+	}
+	wg.Wait()
+
+	// Everyone picked a hero, so recruit starts without waiting for the timer.
+	require.Eventually(t, func() bool {
+		_, phase := lobbyPhase(t, l)
+		return phase == game.PhaseRecruit
+	}, 5*time.Second, 10*time.Millisecond)
+
+	for _, conn := range conns {
+		require.NoError(t, sendAction(conn, api.ActionEndTurn, nil))
+	}
+
+	// All players ended the turn, so combat starts through the phase handler.
+	require.Eventually(t, func() bool {
+		turn, phase := lobbyPhase(t, l)
+		return phase == game.PhaseCombat || turn > 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestTargetIndex(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		payload string
+		want    int
+	}{
+		{`{"hand_index":1}`, -1},
+		{`{"hand_index":1,"target_index":0}`, 0},
+		{`{"hand_index":1,"target_index":2}`, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.payload, func(t *testing.T) {
+			t.Parallel()
+			spell, err := decodePayload[api.PlaySpell](&api.ClientMessage{Payload: []byte(tt.payload)})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, targetIndex(spell.TargetIndex))
+		})
+	}
+}
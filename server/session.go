@@ -0,0 +1,107 @@
+package server
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	json "encoding/json/v2"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ysomad/gigabg/game"
+	"github.com/ysomad/gigabg/lobby"
+)
+
+const sessionTTL = 24 * time.Hour
+
+var (
+	errInvalidToken = errors.New("invalid session token")
+	errTokenExpired = errors.New("session token expired")
+	errStaleToken   = errors.New("session token issued for another lobby")
+)
+
+// session binds a player or a spectator to a lobby. Lobby IDs may be reused,
+// e.g. the dev lobby after a restart, so the session also holds the lobby
+// creation time.
+type session struct {
+	Lobby     string        `json:"lobby"`
+	Created   int64         `json:"created"` // lobby creation, unix nanoseconds
+	Player    game.PlayerID `json:"player,omitzero"`
+	Spectator bool          `json:"spectator,omitzero"` // grants spectating only, Player is the inviting player
+	ExpiresAt int64         `json:"expires_at"`         // unix seconds
+}
+
+// validFor reports whether the session was issued for the lobby.
+func (s session) validFor(l *lobby.Lobby) bool {
+	return s.Lobby == l.ID() && s.Created == l.CreatedAt().UnixNano()
+}
+
+// sessionSigner issues and verifies session tokens. A token is the session
+// and its HMAC-SHA256, so verifying it needs only the key.
+type sessionSigner struct {
+	key []byte
+}
+
+// issue returns a token for the player in the lobby.
+func (s sessionSigner) issue(l *lobby.Lobby, player game.PlayerID) (string, error) {
+	return s.encode(session{
+		Lobby:     l.ID(),
+		Created:   l.CreatedAt().UnixNano(),
+		Player:    player,
+		ExpiresAt: time.Now().Add(sessionTTL).Unix(),
+	})
+}
+
+// grant returns a token letting its holder spectate the lobby with the
+// inviting player's access.
+func (s sessionSigner) grant(l *lobby.Lobby, player game.PlayerID) (string, error) {
+	return s.encode(session{
+		Lobby:     l.ID(),
+		Created:   l.CreatedAt().UnixNano(),
+		Player:    player,
+		Spectator: true,
+		ExpiresAt: time.Now().Add(sessionTTL).Unix(),
+	})
+}
+
+func (s sessionSigner) encode(sess session) (string, error) {
+	payload, err := json.Marshal(sess)
+	if err != nil {
+		return "", err
+	}
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString(payload) + "." + enc.EncodeToString(s.sign(payload)), nil
+}
+
+// verify returns the session of a token issued by issue.
+func (s sessionSigner) verify(token string) (session, error) {
+	enc := base64.RawURLEncoding
+	rawPayload, rawSig, ok := strings.Cut(token, ".")
+	if !ok {
+		return session{}, errInvalidToken
+	}
+	payload, err := enc.DecodeString(rawPayload)
+	if err != nil {
+		return session{}, errInvalidToken
+	}
+	sig, err := enc.DecodeString(rawSig)
+	if err != nil || !hmac.Equal(sig, s.sign(payload)) {
+		return session{}, errInvalidToken
+	}
+
+	var sess session
+	if err := json.Unmarshal(payload, &sess); err != nil {
+		return session{}, errInvalidToken
+	}
+	if time.Now().Unix() > sess.ExpiresAt {
+		return session{}, errTokenExpired
+	}
+	return sess, nil
+}
+
+func (s sessionSigner) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,139 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	json "encoding/json/v2"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ysomad/gigabg/game"
+	"github.com/ysomad/gigabg/game/catalog"
+	"github.com/ysomad/gigabg/lobby"
+)
+
+func newSessionLobby(t *testing.T, id string) *lobby.Lobby {
+	t.Helper()
+	cards, err := catalog.New()
+	require.NoError(t, err)
+	l, err := lobby.New(cards, cards, game.MinPlayers, 0)
+	require.NoError(t, err)
+	t.Cleanup(l.Close)
+	l.SetID(id)
+	return l
+}
+
+func TestSessionSigner_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	l := newSessionLobby(t, "lobby")
+	signer := sessionSigner{key: []byte("key")}
+
+	token, err := signer.issue(l, 3)
+	require.NoError(t, err)
+
+	sess, err := signer.verify(token)
+	require.NoError(t, err)
+	assert.Equal(t, "lobby", sess.Lobby)
+	assert.Equal(t, game.PlayerID(3), sess.Player)
+	assert.True(t, sess.validFor(l))
+}
+
+func TestSessionSigner_verify_Invalid(t *testing.T) {
+	t.Parallel()
+
+	l := newSessionLobby(t, "lobby")
+	signer := sessionSigner{key: []byte("key")}
+	token, err := signer.issue(l, 1)
+	require.NoError(t, err)
+	payload, sig, _ := strings.Cut(token, ".")
+
+	// Same signature over a payload claiming another player.
+	forged, err := json.Marshal(session{Lobby: "lobby", Created: l.CreatedAt().UnixNano(), Player: 2, ExpiresAt: time.Now().Add(time.Hour).Unix()})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"empty", ""},
+		{"no signature", payload},
+		{"tampered signature", payload + "." + base64.RawURLEncoding.EncodeToString(signer.sign(forged))},
+		{"tampered payload", base64.RawURLEncoding.EncodeToString(forged) + "." + sig},
+		{"other key", mustIssue(t, sessionSigner{key: []byte("other")}, l, 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := signer.verify(tt.token)
+			assert.ErrorIs(t, err, errInvalidToken)
+		})
+	}
+}
+
+func TestSessionSigner_verify_Expired(t *testing.T) {
+	t.Parallel()
+
+	signer := sessionSigner{key: []byte("key")}
+	payload, err := json.Marshal(session{Lobby: "lobby", Player: 1, ExpiresAt: time.Now().Add(-time.Minute).Unix()})
+	require.NoError(t, err)
+	enc := base64.RawURLEncoding
+	token := enc.EncodeToString(payload) + "." + enc.EncodeToString(signer.sign(payload))
+
+	_, err = signer.verify(token)
+	assert.ErrorIs(t, err, errTokenExpired)
+}
+
+func TestSession_validFor(t *testing.T) {
+	t.Parallel()
+
+	signer := sessionSigner{key: []byte("key")}
+	l := newSessionLobby(t, "lobby")
+	sess, err := signer.verify(mustIssue(t, signer, l, 1))
+	require.NoError(t, err)
+
+	// A lobby reusing the ID, e.g. the dev lobby after a restart.
+	reused := newSessionLobby(t, "lobby")
+	require.NotEqual(t, l.CreatedAt(), reused.CreatedAt())
+
+	assert.True(t, sess.validFor(l))
+	assert.False(t, sess.validFor(newSessionLobby(t, "other")))
+	assert.False(t, sess.validFor(reused))
+}
+
+func TestServer_handleWS_StaleToken(t *testing.T) {
+	t.Parallel()
+
+	s, ts := newTestServer(t)
+	lobbyID, tokens := createTestLobby(t, ts, game.MinPlayers)
+
+	old, err := s.store.Lobby(lobbyID)
+	require.NoError(t, err)
+	require.NoError(t, s.store.DeleteLobby(lobbyID))
+	old.Close()
+
+	reused := newSessionLobby(t, lobbyID)
+	require.NoError(t, s.AddLobby(reused))
+	token, err := s.addPlayer(reused, 1)
+	require.NoError(t, err)
+
+	url := "ws" + strings.TrimPrefix(ts.URL, "http") + "/ws?token="
+	_, resp, err := websocket.Dial(context.Background(), url+tokens[0], nil)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	dialWS(t, ts, "token="+token)
+}
+
+func mustIssue(t *testing.T, signer sessionSigner, l *lobby.Lobby, player game.PlayerID) string {
+	t.Helper()
+	token, err := signer.issue(l, player)
+	require.NoError(t, err)
+	return token
+}
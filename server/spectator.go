@@ -0,0 +1,373 @@
+package server
+
+import (
+	"context"
+	json "encoding/json/v2"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/coder/websocket"
+
+	"github.com/ysomad/gigabg/api"
+	"github.com/ysomad/gigabg/game"
+	"github.com/ysomad/gigabg/lobby"
+)
+
+// defaultSpectatorDelay is longer than the longest recruit phase, so a
+// spectator can't pass a board on before the fight it matters for.
+const defaultSpectatorDelay = 90 * time.Second
+
+// spectatorBufferSize holds the messages of a spectator delay. A spectator
+// filling it is disconnected rather than shown a game with gaps.
+const spectatorBufferSize = 4096
+
+var (
+	errSpectatorAction = errors.New("spectators can only follow players")
+	errPlayerNotFound  = errors.New("player not found")
+	errSpectatorToken  = errors.New("token only grants spectating")
+	errNotFollowable   = errors.New("only your own player can be followed until you are eliminated")
+	errSpectatorDelay  = fmt.Errorf("spectator delay must be at least the longest recruit phase, %s", game.MaxRecruitDuration)
+)
+
+// spectator is a read-only connection to a lobby following one of its
+// players. Spectators aren't lobby players, they only receive the followed
+// player's view of the game after the server's spectator delay.
+type spectator struct {
+	lobbyID   string
+	player    game.PlayerID // whose session or grant the spectator holds
+	following game.PlayerID // accessed on the lobby goroutine only
+	conn      *websocket.Conn
+	send      chan delayedMessage
+	dropOnce  sync.Once
+}
+
+// delayedMessage is a message queued for a spectator.
+type delayedMessage struct {
+	data   []byte
+	sentAt time.Time
+}
+
+// SetSpectatorDelay sets how long messages to spectators are held back.
+// Shorter delays than the longest recruit phase are rejected.
+// Must be called before the server starts serving.
+func (s *Server) SetSpectatorDelay(d time.Duration) error {
+	if d < game.MaxRecruitDuration {
+		return errSpectatorDelay
+	}
+	s.spectatorDelay = d
+	return nil
+}
+
+// inviteSpectator returns a spectator grant for the lobby. Only the lobby's
+// players may invite spectators, who see what the inviting player may follow.
+func (s *Server) inviteSpectator(w http.ResponseWriter, r *http.Request) {
+	var req api.InviteSpectatorReq
+	if err := json.UnmarshalRead(r.Body, &req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	l, err := s.store.Lobby(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	sess, err := s.signer.verify(req.Token)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if sess.Spectator {
+		http.Error(w, errSpectatorToken.Error(), http.StatusForbidden)
+		return
+	}
+	if !sess.validFor(l) {
+		http.Error(w, errStaleToken.Error(), http.StatusForbidden)
+		return
+	}
+
+	token, err := s.signer.grant(l, sess.Player)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	slog.Info("spectator invited", "lobby", l.ID(), "player", sess.Player)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.MarshalWrite(w, api.InviteSpectatorResp{Token: token}); err != nil {
+		slog.Error("encode failed", "error", err)
+	}
+}
+
+// handleSpectate connects a spectator to the lobby given by the spectate
+// query param, following the player given by the player param. The token
+// param must hold a spectator grant or a player session of the lobby. Other
+// players' views are hidden until the token's player is eliminated, so a
+// player still in the game can't scout opponents.
+func (s *Server) handleSpectate(w http.ResponseWriter, r *http.Request) {
+	lobbyID := r.URL.Query().Get("spectate")
+	id, err := strconv.ParseInt(r.URL.Query().Get("player"), 10, 32)
+	if err != nil {
+		http.Error(w, "player query param required", http.StatusBadRequest)
+		return
+	}
+	following := game.PlayerID(id)
+
+	sess, err := s.signer.verify(r.URL.Query().Get("token"))
+	if err != nil {
+		slog.Info("spectator rejected, invalid token", "lobby", lobbyID, "remote", r.RemoteAddr, "error", err)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	l, err := s.store.Lobby(lobbyID)
+	if err != nil {
+		slog.Info("spectator rejected, lobby not found", "lobby", lobbyID, "error", err)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if !sess.validFor(l) {
+		slog.Info("spectator rejected, token for another lobby", "lobby", lobbyID, "remote", r.RemoteAddr)
+		http.Error(w, errStaleToken.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{
+		OriginPatterns: []string{"*"},
+	})
+	if err != nil {
+		slog.Error("websocket accept failed", "error", err)
+		return
+	}
+
+	sp := &spectator{
+		lobbyID:   lobbyID,
+		player:    sess.Player,
+		following: following,
+		conn:      conn,
+		send:      make(chan delayedMessage, spectatorBufferSize),
+	}
+
+	// Registered before the first state so no later update is missed.
+	s.addSpectator(sp)
+
+	err = l.Do(func(l *lobby.Lobby) error {
+		p, err := followable(l, sp, following)
+		if err != nil {
+			return err
+		}
+		for _, msg := range s.connectState(l, p) {
+			s.sendSpectator(sp, msg)
+		}
+		return nil
+	})
+	if err != nil {
+		s.removeSpectator(sp)
+		if cerr := conn.Close(websocket.StatusPolicyViolation, err.Error()); cerr != nil {
+			slog.Error("close rejected spectator conn", "error", cerr, "lobby", lobbyID)
+		}
+		return
+	}
+
+	slog.Info("spectator connected", "lobby", lobbyID, "following", following, "remote", r.RemoteAddr)
+
+	go s.spectatorWritePump(r.Context(), sp)
+	s.spectatorReadPump(r.Context(), sp)
+}
+
+// spectatorWritePump writes each message once the spectator delay has passed
+// since it was sent.
+func (s *Server) spectatorWritePump(ctx context.Context, sp *spectator) {
+	defer sp.conn.CloseNow() //nolint:errcheck // best-effort cleanup
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-sp.send:
+			if !ok {
+				return
+			}
+			if wait := time.Until(msg.sentAt.Add(s.spectatorDelay)); wait > 0 {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(wait):
+				}
+			}
+			if err := sp.conn.Write(ctx, websocket.MessageBinary, msg.data); err != nil {
+				slog.Error("spectator write failed", "error", err)
+				return
+			}
+		}
+	}
+}
+
+func (s *Server) spectatorReadPump(ctx context.Context, sp *spectator) {
+	defer func() {
+		s.removeSpectator(sp)
+		close(sp.send)
+	}()
+
+	for {
+		_, data, err := sp.conn.Read(ctx)
+		if err != nil {
+			if websocket.CloseStatus(err) == websocket.StatusNormalClosure || errors.Is(err, io.EOF) {
+				return
+			}
+			slog.Error("spectator read failed", "error", err)
+			return
+		}
+
+		var msg api.ClientMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			slog.Error("decode failed", "error", err)
+			continue
+		}
+
+		if msg.Action != api.ActionFollow {
+			s.sendSpectator(sp, &api.ServerMessage{Error: &api.Error{Message: errSpectatorAction.Error()}})
+			continue
+		}
+		payload, err := decodePayload[api.Follow](&msg)
+		if err != nil {
+			s.sendSpectator(sp, &api.ServerMessage{Error: &api.Error{Message: err.Error()}})
+			continue
+		}
+		if err := s.follow(sp, payload.Player); err != nil {
+			s.sendSpectator(sp, &api.ServerMessage{Error: &api.Error{Message: err.Error()}})
+		}
+	}
+}
+
+// follow switches the spectator to the player and sends the player's state.
+func (s *Server) follow(sp *spectator, player game.PlayerID) error {
+	l, err := s.store.Lobby(sp.lobbyID)
+	if err != nil {
+		return err
+	}
+	return l.Do(func(l *lobby.Lobby) error {
+		p, err := followable(l, sp, player)
+		if err != nil {
+			return err
+		}
+		sp.following = player
+		for _, msg := range s.connectState(l, p) {
+			s.sendSpectator(sp, msg)
+		}
+		slog.Info("spectator follows", "lobby", sp.lobbyID, "following", player)
+		return nil
+	})
+}
+
+// followable returns the player if the spectator may follow them: its own
+// player always, anyone else once its own player is eliminated. Runs on the
+// lobby goroutine.
+func followable(l *lobby.Lobby, sp *spectator, player game.PlayerID) (*game.Player, error) {
+	p := l.Player(player)
+	if p == nil {
+		return nil, errPlayerNotFound
+	}
+	if player == sp.player {
+		return p, nil
+	}
+	if own := l.Player(sp.player); own == nil || own.IsAlive() {
+		return nil, errNotFollowable
+	}
+	return p, nil
+}
+
+// sendSpectators sends the message to the spectators following the player.
+func (s *Server) sendSpectators(lobbyID string, player game.PlayerID, msg *api.ServerMessage) {
+	s.specMu.RLock()
+	defer s.specMu.RUnlock()
+
+	for _, sp := range s.spectators[lobbyID] {
+		if sp.following == player {
+			s.sendSpectator(sp, msg)
+		}
+	}
+}
+
+// broadcastSpectatorState sends every spectator the state of the player it
+// follows.
+func (s *Server) broadcastSpectatorState(lobbyID string, l *lobby.Lobby) {
+	s.specMu.RLock()
+	defer s.specMu.RUnlock()
+
+	for _, sp := range s.spectators[lobbyID] {
+		p := l.Player(sp.following)
+		if p == nil {
+			continue
+		}
+		s.sendSpectator(sp, &api.ServerMessage{State: s.playerState(l, p)})
+	}
+}
+
+// sendSpectatorCombatLogs sends spectators the combat log of the player they
+// follow.
+func (s *Server) sendSpectatorCombatLogs(lobbyID string, l *lobby.Lobby) {
+	s.specMu.RLock()
+	defer s.specMu.RUnlock()
+
+	for _, sp := range s.spectators[lobbyID] {
+		log, ok := l.CombatLog(sp.following)
+		if !ok {
+			continue
+		}
+		events, err := api.NewCombatEvents(log.Events)
+		if err != nil {
+			slog.Error("failed to marshal combat events", "player", sp.following, "err", err)
+			continue
+		}
+		s.sendSpectator(sp, &api.ServerMessage{CombatEvents: events})
+	}
+}
+
+func (s *Server) sendSpectator(sp *spectator, msg *api.ServerMessage) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		slog.Error("encode failed", "error", err)
+		return
+	}
+
+	select {
+	case sp.send <- delayedMessage{data: data, sentAt: time.Now()}:
+	default:
+		// Closing ends the read pump, which removes the spectator. Close waits
+		// for the peer, so it can't run on the lobby goroutine.
+		sp.dropOnce.Do(func() {
+			slog.Warn("spectator send buffer full, disconnecting", "lobby", sp.lobbyID)
+			go sp.conn.Close(websocket.StatusTryAgainLater, "spectator fell behind") //nolint:errcheck // best-effort close
+		})
+	}
+}
+
+func (s *Server) addSpectator(sp *spectator) {
+	s.specMu.Lock()
+	defer s.specMu.Unlock()
+	s.spectators[sp.lobbyID] = append(s.spectators[sp.lobbyID], sp)
+}
+
+func (s *Server) removeSpectator(sp *spectator) {
+	s.specMu.Lock()
+	defer s.specMu.Unlock()
+
+	spectators := slices.DeleteFunc(s.spectators[sp.lobbyID], func(q *spectator) bool { return q == sp })
+	if len(spectators) == 0 {
+		delete(s.spectators, sp.lobbyID)
+	} else {
+		s.spectators[sp.lobbyID] = spectators
+	}
+
+	slog.Info("spectator disconnected", "lobby", sp.lobbyID)
+}
@@ -0,0 +1,192 @@
+package server
+
+import (
+	"context"
+	json "encoding/json/v2"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ysomad/gigabg/api"
+	"github.com/ysomad/gigabg/game"
+	"github.com/ysomad/gigabg/lobby"
+)
+
+func spectateQuery(lobbyID string, player game.PlayerID, token string) string {
+	return fmt.Sprintf("spectate=%s&player=%d&token=%s", lobbyID, player, token)
+}
+
+func inviteSpectator(t *testing.T, ts *httptest.Server, lobbyID, token string) (string, int) {
+	t.Helper()
+	var resp api.InviteSpectatorResp
+	status := postJSON(t, ts, "/lobbies/"+lobbyID+"/spectators", api.InviteSpectatorReq{Token: token}, &resp)
+	return resp.Token, status
+}
+
+// readError reads messages until an error and returns its message.
+func readError(t *testing.T, conn *websocket.Conn) string {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for {
+		_, data, err := conn.Read(ctx)
+		require.NoError(t, err)
+		var msg struct {
+			Error *api.Error `json:"error"`
+		}
+		if json.Unmarshal(data, &msg) == nil && msg.Error != nil {
+			return msg.Error.Message
+		}
+	}
+}
+
+// following returns the players followed by the lobby's spectators.
+func following(t *testing.T, s *Server, l *lobby.Lobby) []game.PlayerID {
+	t.Helper()
+	var players []game.PlayerID
+	require.NoError(t, l.Do(func(*lobby.Lobby) error {
+		s.specMu.RLock()
+		defer s.specMu.RUnlock()
+		for _, sp := range s.spectators[l.ID()] {
+			players = append(players, sp.following)
+		}
+		return nil
+	}))
+	return players
+}
+
+func TestServer_SetSpectatorDelay(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		delay   time.Duration
+		wantErr bool
+	}{
+		{0, true},
+		{game.MaxRecruitDuration - time.Second, true},
+		{game.MaxRecruitDuration, false},
+		{defaultSpectatorDelay, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.delay.String(), func(t *testing.T) {
+			t.Parallel()
+			s, _ := newTestServer(t)
+			err := s.SetSpectatorDelay(tt.delay)
+			if tt.wantErr {
+				require.ErrorIs(t, err, errSpectatorDelay)
+				assert.Equal(t, defaultSpectatorDelay, s.spectatorDelay)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.delay, s.spectatorDelay)
+		})
+	}
+}
+
+func TestServer_handleSpectate_Auth(t *testing.T) {
+	t.Parallel()
+
+	s, ts := newTestServer(t)
+	s.spectatorDelay = 0
+	lobbyID, tokens := createTestLobby(t, ts, game.MinPlayers)
+	_, otherTokens := createTestLobby(t, ts, game.MinPlayers)
+
+	grant, status := inviteSpectator(t, ts, lobbyID, tokens[0])
+	require.Equal(t, http.StatusOK, status)
+
+	_, status = inviteSpectator(t, ts, lobbyID, grant)
+	assert.Equal(t, http.StatusForbidden, status, "spectators can't invite")
+	_, status = inviteSpectator(t, ts, lobbyID, otherTokens[0])
+	assert.Equal(t, http.StatusForbidden, status, "players of other lobbies can't invite")
+
+	url := "ws" + strings.TrimPrefix(ts.URL, "http") + "/ws?"
+	rejected := map[string]string{
+		"no token":        spectateQuery(lobbyID, 1, ""),
+		"invalid token":   spectateQuery(lobbyID, 1, "token"),
+		"other lobby":     spectateQuery(lobbyID, 1, otherTokens[0]),
+		"grant as player": "token=" + grant,
+	}
+	for name, query := range rejected {
+		_, resp, err := websocket.Dial(context.Background(), url+query, nil)
+		require.Error(t, err, name)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, name)
+	}
+
+	dialWS(t, ts, spectateQuery(lobbyID, 1, grant))
+	dialWS(t, ts, spectateQuery(lobbyID, 1, tokens[0]))
+
+	// Opponents of a player still in the game are hidden.
+	for name, query := range map[string]string{
+		"grant":  spectateQuery(lobbyID, 2, grant),
+		"player": spectateQuery(lobbyID, 2, tokens[0]),
+	} {
+		conn := dialWS(t, ts, query)
+		_, _, err := conn.Read(context.Background())
+		assert.Equal(t, websocket.StatusPolicyViolation, websocket.CloseStatus(err), name)
+	}
+}
+
+func TestServer_Spectator_Delay(t *testing.T) {
+	t.Parallel()
+
+	const delay = 300 * time.Millisecond
+	s, ts := newTestServer(t)
+	s.spectatorDelay = delay
+	lobbyID, tokens := createTestLobby(t, ts, game.MinPlayers)
+	conn := dialWS(t, ts, spectateQuery(lobbyID, 1, tokens[0]))
+
+	sentAt := time.Now()
+	require.NoError(t, sendAction(conn, api.ActionEndTurn, nil))
+	assert.Equal(t, errSpectatorAction.Error(), readError(t, conn))
+	assert.GreaterOrEqual(t, time.Since(sentAt), delay)
+}
+
+func TestServer_Spectator_Actions(t *testing.T) {
+	t.Parallel()
+
+	s, ts := newTestServer(t)
+	s.spectatorDelay = 0
+	lobbyID, tokens := createTestLobby(t, ts, game.MinPlayers)
+	l, err := s.store.Lobby(lobbyID)
+	require.NoError(t, err)
+	conn := dialWS(t, ts, spectateQuery(lobbyID, 1, tokens[0]))
+
+	for _, action := range []api.Action{api.ActionPickHero, api.ActionBuyCard, api.ActionEndTurn} {
+		require.NoError(t, sendAction(conn, action, api.PickHero{Index: 0}))
+		assert.Equal(t, errSpectatorAction.Error(), readError(t, conn), action)
+	}
+
+	require.NoError(t, sendAction(conn, api.ActionFollow, api.Follow{Player: 9}))
+	assert.Equal(t, errPlayerNotFound.Error(), readError(t, conn))
+	assert.Equal(t, []game.PlayerID{1}, following(t, s, l))
+
+	require.NoError(t, sendAction(conn, api.ActionFollow, api.Follow{Player: 2}))
+	assert.Equal(t, errNotFollowable.Error(), readError(t, conn))
+	assert.Equal(t, []game.PlayerID{1}, following(t, s, l))
+
+	// Anyone can be followed once the spectator's own player is eliminated.
+	require.NoError(t, l.Do(func(l *lobby.Lobby) error {
+		p := l.Player(1)
+		p.TakeDamage(p.Health())
+		return nil
+	}))
+	require.NoError(t, sendAction(conn, api.ActionFollow, api.Follow{Player: 2}))
+	require.Eventually(t, func() bool {
+		players := following(t, s, l)
+		return len(players) == 1 && players[0] == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Spectator actions don't reach the lobby.
+	require.NoError(t, l.Do(func(l *lobby.Lobby) error {
+		assert.Equal(t, game.PhaseHeroSelect, l.Phase())
+		assert.False(t, l.Ready(1))
+		return nil
+	}))
+}
@@ -20,6 +20,7 @@ const (
 	KindSpawnGlow
 	KindVenomBreak
 	KindWindfury
+	KindTriggerGlow
 )
 
 // Effect is a visual effect attached to a combat minion.
@@ -0,0 +1,62 @@
+package effect
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/ysomad/gigabg/ui"
+)
+
+// TriggerGlow draws a pulsing golden outline when a minion's triggered
+// ability (e.g. Avenge) fires.
+type TriggerGlow struct {
+	timer    float64
+	duration float64
+
+	glowColor color.RGBA
+}
+
+var _ Effect = (*TriggerGlow)(nil)
+
+func NewTriggerGlow(duration float64) *TriggerGlow {
+	return &TriggerGlow{
+		timer:     duration,
+		duration:  duration,
+		glowColor: color.RGBA{255, 200, 60, 255},
+	}
+}
+
+func (e *TriggerGlow) Kind() Kind { return KindTriggerGlow }
+
+func (e *TriggerGlow) Update(elapsed float64) bool {
+	e.timer -= elapsed
+	if e.timer < 0 {
+		e.timer = 0
+	}
+	return e.timer <= 0
+}
+
+func (e *TriggerGlow) Progress() float64 {
+	return 1.0 - e.timer/e.duration
+}
+
+func (e *TriggerGlow) Modify(*ui.Rect, *uint8, *float64)               {}
+func (e *TriggerGlow) DrawFront(*ebiten.Image, ui.Resolution, ui.Rect) {}
+
+func (e *TriggerGlow) DrawBehind(screen *ebiten.Image, res ui.Resolution, rect ui.Rect) {
+	sr := rect.Screen(res)
+	s := float32(res.Scale())
+	t := float32(e.Progress())
+
+	// Outline grows outward while fading.
+	pad := s * (2 + 8*t)
+	gc := e.glowColor
+	gc.A = uint8(220 * (1 - t))
+	vector.StrokeRect(screen,
+		float32(sr.X)-pad, float32(sr.Y)-pad,
+		float32(sr.W)+2*pad, float32(sr.H)+2*pad,
+		s*3, gc, true,
+	)
+}
@@ -142,3 +142,17 @@ func ButtonRects(zone Rect) (Rect, Rect, Rect) {
 	freeze := Rect{startX + 2*(btnW+gap), y, btnW, btnH}
 	return refresh, upgrade, freeze
 }
+
+// EndTurnRect returns the end turn button rect left of the timer in a header zone.
+func EndTurnRect(zone Rect) Rect {
+	btnW := zone.W * 0.1
+	btnH := zone.H * 0.7
+	return Rect{zone.X + zone.W*0.87 - btnW, zone.Y + (zone.H-btnH)/2, btnW, btnH}
+}
+
+// HeroPowerRect returns the hero power button rect at the right of a zone.
+func HeroPowerRect(zone Rect) Rect {
+	btnW := zone.W * 0.16
+	btnH := zone.H * 0.7
+	return Rect{zone.X + zone.W*0.96 - btnW, zone.Y + (zone.H-btnH)/2, btnW, btnH}
+}
@@ -9,8 +9,10 @@ import (
 type PlayerEntry struct {
 	ID            game.PlayerID
 	HP            int
+	Armor         int
 	ShopTier      game.Tier
 	CombatResults []api.CombatResult
 	TopTribe      game.Tribe
 	TopTribeCount int
+	Ready         bool // ended turn
 }
@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"math"
 	"slices"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
@@ -20,16 +21,27 @@ import (
 	"github.com/ysomad/gigabg/ui/widget"
 )
 
-// Animation timing (seconds).
+// eventTime is the animation time per combat event (seconds). An attack
+// spends two events moving and pausing and its death one, so the replay
+// fits the combat phase the server schedules from game.CombatEventDuration.
+const eventTime = float64(game.CombatEventDuration) / float64(time.Second)
+
+// Attack and death timing (seconds), derived from eventTime.
+const (
+	attackMoveDuration = 0.9 * eventTime // attacker moves to target
+	attackBackDuration = 0.7 * eventTime // attacker returns to slot
+	eventPause         = 0.4 * eventTime // pause between attacks
+	deathFadeDuration  = eventTime       // opacity fade on death
+)
+
+// Effect timing (seconds). Effects overlap the attack, so they don't add to
+// the time per event.
 const (
-	attackMoveDuration    = 0.55 // attacker moves to target
-	attackBackDuration    = 0.45 // attacker returns to slot
 	damageFlashTime       = 0.20 // initial white flash on hit
 	damageShakeTime       = 0.50 // shake duration after hit
 	shakeFreq             = 35.0 // shake frequency multiplier
 	hitFlashTime          = 0.80 // hit damage indicator on minion body
 	venomEffectTime       = 0.60 // green drip/splash on venomed target before death
-	deathFadeDuration     = 0.50 // opacity fade on death
 	deathParticleTime     = 0.80 // particle burst duration
 	deathParticleCount    = 12   // number of particles on death
 	divineShieldBreakTime = 0.50 // divine shield shard burst
@@ -37,7 +49,7 @@ const (
 	rebornDelayTime       = 0.30 // pause after death before reborn glow starts
 	spawnGlowTime         = 0.70 // blue glow pillar on reborn
 	spawnFadeStart        = 0.4  // glow progress fraction when opacity fade-in begins
-	eventPause            = 0.30 // pause between attacks
+	triggerGlowTime       = 0.60 // golden outline when a triggered ability fires
 )
 
 // animPhase tracks the two-phase attacker movement.
@@ -48,8 +60,8 @@ const (
 	animPhaseBack
 )
 
-// pendingReborn holds data for a reborn animation that triggers after the
-// dying minion is fully removed from the board.
+// pendingReborn holds data for a reborn or summon animation that triggers
+// after the dying minion is fully removed from the board.
 type pendingReborn struct {
 	card     api.Card
 	isPlayer bool
@@ -114,6 +126,8 @@ type attackAnimation struct {
 // combatBoard replays combat events as visual animations using GameLayout zones.
 // Opponent board renders in the Shop zone, player board in the Board zone.
 //
+// Start of Combat events play before the first attack.
+//
 // Animation sequence per attack:
 //  1. Attacker moves forward to target (eased)
 //  2. Impact: process events (damage, shield breaks, keyword removals)
@@ -135,6 +149,7 @@ type combatBoard struct {
 	events     []api.CombatEvent
 	eventIndex int
 	pauseTimer float64
+	speed      float64 // playback speed, above 1 for fights longer than the phase
 
 	attackAnim *attackAnimation
 	done       bool
@@ -145,8 +160,7 @@ type combatBoard struct {
 	// Pending reborn animations waiting for death fade to complete.
 	pendingReborns []pendingReborn
 
-	// Set by markDying so rebornMinion can record the position.
-	lastDeathIdx      int
+	// Set by markDying so queueReborn knows the side.
 	lastDeathIsPlayer bool
 }
 
@@ -171,6 +185,7 @@ func newCombatBoard(
 		playerBoard:   buildAnimBoard(playerBoard),
 		opponentBoard: buildAnimBoard(opponentBoard),
 		events:        events,
+		speed:         game.CombatPlaybackSpeed(len(events)),
 	}
 }
 
@@ -184,6 +199,7 @@ func buildAnimBoard(cards []api.Card) []*animMinion {
 
 // Update advances animation state. Returns true when all animations are done.
 func (cp *combatBoard) Update(elapsed float64, res ui.Resolution, lay ui.GameLayout) (bool, error) {
+	elapsed *= cp.speed
 	cp.cr.Res = res
 	cp.cr.Tick++
 	cp.cr.UpdateEffects(elapsed)
@@ -226,16 +242,28 @@ func (cp *combatBoard) Update(elapsed float64, res ui.Resolution, lay ui.GameLay
 	// Scan for the next AttackEvent to start a new animation.
 	for cp.eventIndex < len(cp.events) {
 		ev := cp.events[cp.eventIndex]
-		cp.eventIndex++
 
-		if ev.Type == game.CombatEventAttack {
-			var e game.AttackEvent
-			if err := json.Unmarshal(ev.Payload, &e); err != nil {
-				return false, fmt.Errorf("unmarshal attack event: %w", err)
+		if ev.Type != game.CombatEventAttack {
+			// Events outside an attack (Start of Combat effects) play on
+			// their own; the next Update waits for their visuals.
+			start := cp.eventIndex
+			if err := cp.processEvents(lay); err != nil {
+				return false, err
+			}
+			if cp.eventIndex > start {
+				return false, nil
 			}
-			cp.startAttack(e, lay)
-			return false, nil
+			cp.eventIndex++ // not animated
+			continue
+		}
+
+		cp.eventIndex++
+		var e game.AttackEvent
+		if err := json.Unmarshal(ev.Payload, &e); err != nil {
+			return false, fmt.Errorf("unmarshal attack event: %w", err)
 		}
+		cp.startAttack(e, lay)
+		return false, nil
 	}
 
 	// All events consumed; wait for remaining visuals to finish.
@@ -444,6 +472,14 @@ func (cp *combatBoard) processEvents(lay ui.GameLayout) error {
 			err = unmarshalApply(ev.Payload, cp.markDying)
 		case game.CombatEventReborn:
 			err = unmarshalApply(ev.Payload, cp.queueReborn)
+		case game.CombatEventSummon:
+			err = unmarshalApply(ev.Payload, cp.queueSummon)
+		case game.CombatEventBuff:
+			err = unmarshalApply(ev.Payload, cp.applyBuff)
+		case game.CombatEventTrigger:
+			err = unmarshalApply(ev.Payload, cp.highlightTrigger)
+		case game.CombatEventAura:
+			err = unmarshalApply(ev.Payload, cp.applyAura)
 		default:
 			return nil
 		}
@@ -493,7 +529,6 @@ func (cp *combatBoard) markDying(ev game.DeathEvent) error {
 		return fmt.Errorf("minion %d not found", ev.Target)
 	}
 
-	cp.lastDeathIdx = idx
 	cp.lastDeathIsPlayer = isPlayer
 
 	m := cp.boardFor(isPlayer)[idx]
@@ -539,12 +574,98 @@ func (cp *combatBoard) queueReborn(ev game.RebornEvent) error {
 	cp.pendingReborns = append(cp.pendingReborns, pendingReborn{
 		card:     card,
 		isPlayer: cp.lastDeathIsPlayer,
-		boardIdx: cp.lastDeathIdx,
+		boardIdx: ev.Position,
 		delay:    rebornDelayTime,
 	})
 	return nil
 }
 
+// queueSummon records a minion summoned by a deathrattle. Like reborn, it
+// appears after the dying minion has faded out.
+func (cp *combatBoard) queueSummon(ev game.SummonEvent) error {
+	t := cp.cr.Cards.ByTemplateID(ev.Template)
+	if t == nil {
+		return fmt.Errorf("unknown template %q", ev.Template)
+	}
+
+	card := api.Card{
+		Template: ev.Template,
+		Attack:   ev.Attack,
+		Health:   ev.Health,
+		IsGolden: ev.Golden,
+		Tribes:   t.Tribes(),
+		Keywords: ev.Keywords,
+		CombatID: ev.Target,
+	}
+
+	cp.pendingReborns = append(cp.pendingReborns, pendingReborn{
+		card:     card,
+		isPlayer: ev.Owner == cp.player,
+		boardIdx: ev.Position,
+		delay:    rebornDelayTime,
+	})
+	return nil
+}
+
+// findCard returns the card with the given combat ID. The minion may still be
+// waiting to spawn if it was summoned earlier in the same death sequence; m is
+// nil then.
+func (cp *combatBoard) findCard(id game.CombatID) (card *api.Card, m *animMinion) {
+	if idx, isPlayer := cp.findMinion(id); idx >= 0 {
+		m = cp.boardFor(isPlayer)[idx]
+		return &m.card, m
+	}
+	for i := range cp.pendingReborns {
+		if cp.pendingReborns[i].card.CombatID == id {
+			return &cp.pendingReborns[i].card, nil
+		}
+	}
+	return nil, nil
+}
+
+// applyBuff adds stats and keyword from a buff event.
+func (cp *combatBoard) applyBuff(ev game.BuffEvent) error {
+	card, m := cp.findCard(ev.Target)
+	if card == nil {
+		return fmt.Errorf("minion %d not found", ev.Target)
+	}
+	if m != nil {
+		m.effects.Add(effect.NewFlash(damageFlashTime))
+	}
+
+	card.Attack += ev.Attack
+	card.Health += ev.Health
+	if ev.Keyword != 0 {
+		card.Keywords.Add(ev.Keyword)
+	}
+	return nil
+}
+
+// applyAura sets stats after an aura source entered or left the board.
+func (cp *combatBoard) applyAura(ev game.AuraEvent) error {
+	card, _ := cp.findCard(ev.Target)
+	if card == nil {
+		return fmt.Errorf("minion %d not found", ev.Target)
+	}
+
+	card.Attack = ev.Attack
+	card.Health = ev.Health
+	card.AuraAttack = ev.AuraAttack > 0
+	card.AuraHealth = ev.AuraHealth > 0
+	card.Keywords = ev.Keywords
+	return nil
+}
+
+// highlightTrigger outlines the minion whose triggered ability fired.
+func (cp *combatBoard) highlightTrigger(ev game.TriggerEvent) error {
+	idx, isPlayer := cp.findMinion(ev.Source)
+	if idx < 0 {
+		return fmt.Errorf("minion %d not found", ev.Source)
+	}
+	cp.boardFor(isPlayer)[idx].effects.Add(effect.NewTriggerGlow(triggerGlowTime))
+	return nil
+}
+
 // updatePendingReborns ticks pending reborn delays. Once a reborn's owning
 // dying minion has been removed and the delay elapses, the new minion is
 // inserted into the board with a glow + fade-in animation.
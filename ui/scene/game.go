@@ -23,6 +23,7 @@ type Game struct {
 	font     *text.GoTextFace
 	boldFont *text.GoTextFace
 
+	heroSelect   *heroSelectPhase
 	recruit      *recruitPhase
 	combat       *combatBoard
 	sidebar      *widget.Sidebar
@@ -46,6 +47,9 @@ func NewGame(c *client.GameClient, cs *catalog.Catalog, font, boldFont *text.GoT
 		cr:       cr,
 		font:     font,
 		boldFont: boldFont,
+		heroSelect: &heroSelectPhase{
+			client: c,
+		},
 		recruit: &recruitPhase{
 			client: c,
 			cr:     cr,
@@ -136,6 +140,10 @@ func (g *Game) Update(res ui.Resolution) error {
 		return nil
 	}
 
+	if phase == game.PhaseHeroSelect {
+		return g.heroSelect.Update(res)
+	}
+
 	if phase == game.PhaseRecruit {
 		return g.recruit.Update(res, g.lay)
 	}
@@ -154,6 +162,8 @@ func (g *Game) Draw(screen *ebiten.Image, res ui.Resolution) {
 	switch g.client.Phase() {
 	case game.PhaseWaiting:
 		g.drawWaiting(screen, res)
+	case game.PhaseHeroSelect:
+		g.heroSelect.Draw(screen, res, g.font, g.timeRemaining())
 	case game.PhaseRecruit:
 		if g.combat != nil {
 			g.drawCombat(screen, res)
@@ -375,6 +385,8 @@ func (g *Game) onPhaseTransition(from, to game.Phase) {
 		// Recruit -> Combat or Recruit -> Finished (game ended during combat).
 		g.phaseToast.Show("COMBAT")
 		g.recruit.ReorderCards()
+		g.recruit.battlecry = nil
+		g.recruit.heroPower = false
 	case to == game.PhaseRecruit && g.combat == nil:
 		g.phaseToast.Show("RECRUIT")
 	case from == game.PhaseCombat && to == game.PhaseFinished:
@@ -0,0 +1,134 @@
+package scene
+
+import (
+	"fmt"
+	"image/color"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/ysomad/gigabg/api"
+	"github.com/ysomad/gigabg/client"
+	"github.com/ysomad/gigabg/game"
+	"github.com/ysomad/gigabg/ui"
+)
+
+// heroSelectPhase handles input and drawing while players pick their heroes.
+type heroSelectPhase struct {
+	client *client.GameClient
+}
+
+// heroRect returns the rect of the i-th of n hero offers, centered on screen.
+func heroRect(i, n int) ui.Rect {
+	w := float64(ui.BaseWidth)
+	h := float64(ui.BaseHeight)
+	cardW := w * 0.22
+	cardH := h * 0.45
+	gap := w * 0.03
+	totalW := float64(n)*cardW + float64(n-1)*gap
+	startX := (w - totalW) / 2
+	return ui.Rect{X: startX + float64(i)*(cardW+gap), Y: h * 0.25, W: cardW, H: cardH}
+}
+
+// Update picks the clicked hero offer.
+func (h *heroSelectPhase) Update(res ui.Resolution) error {
+	if !inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		return nil
+	}
+	offers := h.client.HeroOffers()
+	mx, my := ebiten.CursorPosition()
+	for i := range offers {
+		if heroRect(i, len(offers)).Contains(res, mx, my) {
+			if err := h.client.PickHero(i); err != nil {
+				slog.Error("pick hero", "error", err)
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+// Draw renders hero offers, or the picked hero while others are choosing.
+func (h *heroSelectPhase) Draw(
+	screen *ebiten.Image,
+	res ui.Resolution,
+	font *text.GoTextFace,
+	timeRemaining time.Duration,
+) {
+	w := float64(ui.BaseWidth)
+	hh := float64(ui.BaseHeight)
+
+	secs := int(timeRemaining.Seconds())
+	ui.DrawText(screen, res, font, fmt.Sprintf("%d:%02d", secs/60, secs%60),
+		w*0.9, hh*0.05, color.RGBA{255, 255, 255, 255})
+
+	if tribes := h.client.Tribes(); tribes != 0 {
+		ui.DrawText(screen, res, font, "Tribes: "+tribeNames(tribes),
+			w*0.04, hh*0.05, color.RGBA{150, 200, 255, 255})
+	}
+
+	offers := h.client.HeroOffers()
+	if len(offers) == 0 {
+		msg := "Waiting for other players..."
+		if p := h.client.Player(); p != nil && p.Hero != nil {
+			msg = fmt.Sprintf("You picked %s. Waiting for other players...", p.Hero.Name)
+		}
+		ui.DrawText(screen, res, font, msg, w*0.32, hh*0.45, color.RGBA{200, 200, 200, 255})
+		return
+	}
+
+	ui.DrawText(screen, res, font, "Choose your hero", w*0.42, hh*0.12, color.RGBA{255, 215, 0, 255})
+
+	mx, my := ebiten.CursorPosition()
+	for i, hero := range offers {
+		rect := heroRect(i, len(offers))
+		drawHeroOffer(screen, res, font, hero, rect, rect.Contains(res, mx, my))
+	}
+}
+
+// tribeNames returns a comma-separated list of tribe names.
+func tribeNames(t game.Tribes) string {
+	list := t.List()
+	names := make([]string, len(list))
+	for i, tr := range list {
+		names[i] = tr.String()
+	}
+	return strings.Join(names, ", ")
+}
+
+func drawHeroOffer(screen *ebiten.Image, res ui.Resolution, font *text.GoTextFace, hero api.Hero, rect ui.Rect, hovered bool) {
+	sr := rect.Screen(res)
+	border := color.RGBA{100, 100, 140, 255}
+	if hovered {
+		border = color.RGBA{255, 215, 0, 255}
+	}
+	vector.FillRect(screen, float32(sr.X), float32(sr.Y), float32(sr.W), float32(sr.H),
+		color.RGBA{40, 40, 60, 255}, false)
+	vector.StrokeRect(screen, float32(sr.X), float32(sr.Y), float32(sr.W), float32(sr.H),
+		2*float32(res.Scale()), border, false)
+
+	x := rect.X + rect.W*0.06
+	lineH := rect.H * 0.1
+
+	ui.DrawText(screen, res, font, hero.Name, x, rect.Y+lineH, color.RGBA{255, 255, 255, 255})
+	ui.DrawText(screen, res, font, fmt.Sprintf("Health: %d", hero.HP), x, rect.Y+2*lineH,
+		color.RGBA{230, 80, 80, 255})
+	if hero.Armor > 0 {
+		ui.DrawText(screen, res, font, fmt.Sprintf("Armor: %d", hero.Armor), x, rect.Y+3*lineH,
+			color.RGBA{170, 170, 190, 255})
+	}
+
+	power := hero.Power.Name
+	if hero.Power.Passive {
+		power += " (Passive)"
+	} else {
+		power += fmt.Sprintf(" (%dg)", hero.Power.Cost)
+	}
+	ui.DrawText(screen, res, font, power, x, rect.Y+4*lineH, color.RGBA{255, 215, 0, 255})
+	ui.DrawText(screen, res, font, hero.Power.Description, x, rect.Y+5*lineH, color.RGBA{200, 200, 200, 255})
+}
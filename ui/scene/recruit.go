@@ -28,6 +28,15 @@ type recruitPhase struct {
 	hover hoverTooltip
 
 	boardOrder []int
+	battlecry  *pendingBattlecry
+	heroPower  bool // waiting for the hero power target
+}
+
+// pendingBattlecry is a minion dropped on the board that waits for the player
+// to pick its Battlecry target.
+type pendingBattlecry struct {
+	handIdx int
+	pos     int
 }
 
 // ReorderCards sends the local board/shop order to the server.
@@ -63,6 +72,18 @@ func (r *recruitPhase) Update(res ui.Resolution, lay ui.GameLayout) error {
 		return nil
 	}
 
+	// Battlecry target prompt blocks all other input.
+	if r.battlecry != nil {
+		r.handleBattlecryTarget(res, lay, mx, my)
+		return nil
+	}
+
+	// Hero power target prompt blocks all other input.
+	if r.heroPower {
+		r.handleHeroPowerTarget(res, lay, mx, my)
+		return nil
+	}
+
 	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
 		if r.handleStartDrag(res, lay, mx, my) {
 			return nil
@@ -84,6 +105,12 @@ func (r *recruitPhase) Update(res ui.Resolution, lay ui.GameLayout) error {
 		if r.shop.handleButtonClick(res, lay, mx, my) {
 			return nil
 		}
+		if r.handleHeroPowerClick(res, lay, mx, my) {
+			return nil
+		}
+		if r.handleEndTurnClick(res, lay, mx, my) {
+			return nil
+		}
 	}
 
 	if !r.drag.active {
@@ -104,17 +131,11 @@ func (r *recruitPhase) handleStartDrag(res ui.Resolution, lay ui.GameLayout, mx,
 	}
 
 	hand := r.client.Hand()
-	for i, c := range hand {
+	for i := range hand {
 		rect := ui.CardRect(lay.Hand, i, len(hand), lay.CardW, lay.CardH, lay.Gap)
 		if !rect.Contains(res, mx, my) {
 			continue
 		}
-		if t := r.cr.Cards.ByTemplateID(c.Template); t != nil && t.Kind() == game.CardKindSpell {
-			if err := r.client.PlaySpell(i); err != nil {
-				slog.Error("play spell", "error", err)
-			}
-			return true
-		}
 		r.drag.Start(i, false, false, mx, my)
 		return true
 	}
@@ -199,10 +220,130 @@ func (r *recruitPhase) endHandDrag(res ui.Resolution, lay ui.GameLayout, mx, my
 		return
 	}
 
+	if r.draggingSpell() {
+		// Dropped on a minion: cast on it. Elsewhere on the board: cast
+		// without a target, the server rejects spells that need one.
+		target := -1
+		if i := r.boardMinionAt(res, lay, mx, my); i >= 0 {
+			target = r.boardOrder[i]
+		}
+		if err := r.client.PlaySpell(r.drag.index, target); err != nil {
+			slog.Error("play spell", "error", err)
+		}
+		return
+	}
+
 	pos := r.getBoardDropPosition(res, lay, mx)
-	if err := r.client.PlaceMinion(r.drag.index, pos); err != nil {
+
+	hand := r.client.Hand()
+	t := r.cr.Cards.ByTemplateID(hand[r.drag.index].Template)
+	if t != nil && game.NeedsTarget(t) && len(r.boardOrder) > 0 {
+		r.battlecry = &pendingBattlecry{handIdx: r.drag.index, pos: pos}
+		return
+	}
+
+	if err := r.client.PlaceMinion(r.drag.index, pos, -1); err != nil {
+		slog.Error("place minion", "error", err)
+	}
+}
+
+// handleBattlecryTarget plays the pending minion on the clicked board minion.
+// Right click cancels and keeps the minion in hand.
+func (r *recruitPhase) handleBattlecryTarget(res ui.Resolution, lay ui.GameLayout, mx, my int) {
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
+		r.battlecry = nil
+		return
+	}
+	if !inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		return
+	}
+	i := r.boardMinionAt(res, lay, mx, my)
+	if i < 0 {
+		return
+	}
+	if err := r.client.PlaceMinion(r.battlecry.handIdx, r.battlecry.pos, r.boardOrder[i]); err != nil {
 		slog.Error("place minion", "error", err)
 	}
+	r.battlecry = nil
+}
+
+// handleHeroPowerClick uses the hero power, or asks for a target when the power
+// is aimed at a friendly minion.
+func (r *recruitPhase) handleHeroPowerClick(res ui.Resolution, lay ui.GameLayout, mx, my int) bool {
+	if !ui.HeroPowerRect(lay.BtnRow).Contains(res, mx, my) {
+		return false
+	}
+	p := r.client.Player()
+	if p == nil || p.Hero == nil || p.Hero.Power.Passive || p.Hero.Power.Used {
+		return true
+	}
+	if p.Hero.Power.NeedsTarget {
+		r.heroPower = true
+		return true
+	}
+	if err := r.client.UseHeroPower(-1); err != nil {
+		slog.Error("use hero power", "error", err)
+	}
+	return true
+}
+
+// handleEndTurnClick ends the turn. The button does nothing while the turn is
+// already ended, any other action takes it back.
+func (r *recruitPhase) handleEndTurnClick(res ui.Resolution, lay ui.GameLayout, mx, my int) bool {
+	if !ui.EndTurnRect(lay.Header).Contains(res, mx, my) {
+		return false
+	}
+	if p := r.client.Player(); p == nil || p.Ready {
+		return true
+	}
+	if err := r.client.EndTurn(); err != nil {
+		slog.Error("end turn", "error", err)
+	}
+	return true
+}
+
+// handleHeroPowerTarget uses the hero power on the clicked board minion.
+// Right click cancels.
+func (r *recruitPhase) handleHeroPowerTarget(res ui.Resolution, lay ui.GameLayout, mx, my int) {
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
+		r.heroPower = false
+		return
+	}
+	if !inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		return
+	}
+	i := r.boardMinionAt(res, lay, mx, my)
+	if i < 0 {
+		return
+	}
+	if err := r.client.UseHeroPower(r.boardOrder[i]); err != nil {
+		slog.Error("use hero power", "error", err)
+	}
+	r.heroPower = false
+}
+
+// draggingSpell reports whether a spell is being dragged from hand.
+func (r *recruitPhase) draggingSpell() bool {
+	if !r.drag.fromHand() {
+		return false
+	}
+	hand := r.client.Hand()
+	if r.drag.index < 0 || r.drag.index >= len(hand) {
+		return false
+	}
+	t := r.cr.Cards.ByTemplateID(hand[r.drag.index].Template)
+	return t != nil && t.Kind() == game.CardKindSpell
+}
+
+// boardMinionAt returns the display index of the board minion under the cursor, or -1.
+func (r *recruitPhase) boardMinionAt(res ui.Resolution, lay ui.GameLayout, mx, my int) int {
+	for i := range r.boardOrder {
+		rect := ui.CardRect(lay.Board, i, len(r.boardOrder), lay.CardW, lay.CardH, lay.Gap)
+		if rect.Contains(res, mx, my) {
+			return i
+		}
+	}
+	return -1
 }
 
 func (r *recruitPhase) getBoardDropPosition(res ui.Resolution, lay ui.GameLayout, mx int) int {
@@ -250,6 +391,8 @@ func (r *recruitPhase) Draw(
 	r.drawHeader(screen, res, font, lay, turn, timeRemaining)
 	r.drawPlayerStats(screen, res, font, lay)
 	r.shop.drawButtons(screen, res, font, lay)
+	r.drawHeroPower(screen, res, font, lay)
+	r.drawEndTurn(screen, res, font, lay)
 
 	ui.DrawText(screen, res, font, "SHOP",
 		lay.Shop.X+lay.Shop.W*0.04, lay.Shop.Y+lay.Shop.H*0.02,
@@ -261,6 +404,7 @@ func (r *recruitPhase) Draw(
 		color.RGBA{150, 150, 150, 255})
 	r.drawBoardCards(screen, lay)
 	r.drawMagnetizeHighlight(screen, res, lay)
+	r.drawTargetHighlight(screen, res, lay)
 
 	ui.DrawText(screen, res, font, "HAND",
 		lay.Hand.X+lay.Hand.W*0.04, lay.Hand.Y+lay.Hand.H*0.02,
@@ -270,6 +414,12 @@ func (r *recruitPhase) Draw(
 	r.drawDraggedCard(screen, res, lay)
 	r.hover.Draw(screen, lay, r.cr)
 
+	if r.battlecry != nil || r.heroPower {
+		ui.DrawText(screen, res, font, "Choose a target (right click to cancel)",
+			lay.Board.X+lay.Board.W*0.35, lay.Board.Y+lay.Board.H*0.02,
+			color.RGBA{255, 215, 0, 255})
+	}
+
 	if discover := r.client.Discovers(); discover != nil {
 		r.drawDiscoverOverlay(screen, res, font, lay, discover)
 	}
@@ -284,6 +434,9 @@ func (r *recruitPhase) drawHeader(
 	timeRemaining time.Duration,
 ) {
 	header := fmt.Sprintf("Turn %d", turn)
+	if state := r.client.State(); state != nil && state.IsGhost {
+		header += fmt.Sprintf(" | Next: ghost of %d", state.Opponent)
+	}
 	ui.DrawText(screen, res, font, header,
 		lay.Header.X+lay.Header.W*0.04, lay.Header.H*0.5,
 		color.RGBA{200, 200, 200, 255})
@@ -322,6 +475,69 @@ func (r *recruitPhase) drawPlayerStats(
 		color.RGBA{255, 215, 0, 255})
 }
 
+// drawHeroPower draws the hero power button, dimmed when it can't be used.
+func (r *recruitPhase) drawHeroPower(
+	screen *ebiten.Image,
+	res ui.Resolution,
+	font *text.GoTextFace,
+	lay ui.GameLayout,
+) {
+	p := r.client.Player()
+	if p == nil || p.Hero == nil {
+		return
+	}
+	power := p.Hero.Power
+
+	label := fmt.Sprintf("%s (%dg)", power.Name, power.Cost)
+	fill := color.RGBA{90, 60, 30, 255}
+	txt := color.RGBA{255, 215, 0, 255}
+	switch {
+	case power.Passive:
+		label = power.Name
+		fill = color.RGBA{50, 50, 60, 255}
+		txt = color.RGBA{180, 180, 180, 255}
+	case power.Used || p.Gold < power.Cost:
+		fill = color.RGBA{50, 50, 60, 255}
+		txt = color.RGBA{120, 120, 120, 255}
+	}
+
+	btn := widget.Button{
+		Rect:      ui.HeroPowerRect(lay.BtnRow),
+		Text:      label,
+		Color:     fill,
+		BorderClr: color.RGBA{140, 110, 60, 255},
+		TextClr:   txt,
+	}
+	btn.Draw(screen, res, font)
+}
+
+// drawEndTurn draws the end turn button, dimmed once the turn is ended.
+func (r *recruitPhase) drawEndTurn(
+	screen *ebiten.Image,
+	res ui.Resolution,
+	font *text.GoTextFace,
+	lay ui.GameLayout,
+) {
+	p := r.client.Player()
+	if p == nil {
+		return
+	}
+
+	btn := widget.Button{
+		Rect:      ui.EndTurnRect(lay.Header),
+		Text:      "End Turn",
+		Color:     color.RGBA{40, 90, 40, 255},
+		BorderClr: color.RGBA{80, 160, 80, 255},
+		TextClr:   color.RGBA{255, 255, 255, 255},
+	}
+	if p.Ready {
+		btn.Text = "Ready"
+		btn.Color = color.RGBA{50, 50, 60, 255}
+		btn.TextClr = color.RGBA{120, 120, 120, 255}
+	}
+	btn.Draw(screen, res, font)
+}
+
 func (r *recruitPhase) drawBoardCards(screen *ebiten.Image, lay ui.GameLayout) {
 	board := r.client.Board()
 	for i, serverIdx := range r.boardOrder {
@@ -420,6 +636,30 @@ func (r *recruitPhase) drawMagnetizeHighlight(screen *ebiten.Image, res ui.Resol
 	}
 }
 
+// drawTargetHighlight outlines the board minion under the cursor while a
+// spell is dragged or a Battlecry target is being chosen.
+func (r *recruitPhase) drawTargetHighlight(screen *ebiten.Image, res ui.Resolution, lay ui.GameLayout) {
+	var mx, my int
+	switch {
+	case r.draggingSpell():
+		mx, my = r.drag.cursorX, r.drag.cursorY
+	case r.battlecry != nil, r.heroPower:
+		mx, my = ebiten.CursorPosition()
+	default:
+		return
+	}
+	i := r.boardMinionAt(res, lay, mx, my)
+	if i < 0 {
+		return
+	}
+	rect := ui.CardRect(lay.Board, i, len(r.boardOrder), lay.CardW, lay.CardH, lay.Gap)
+	sr := rect.Screen(res)
+	vector.StrokeRect(screen,
+		float32(sr.X), float32(sr.Y), float32(sr.W), float32(sr.H),
+		2*float32(res.Scale()),
+		color.RGBA{255, 215, 0, 200}, false)
+}
+
 func (r *recruitPhase) drawDiscoverOverlay(
 	screen *ebiten.Image,
 	res ui.Resolution,
@@ -118,9 +118,9 @@ func (r *CardRenderer) drawCardMinion(screen *ebiten.Image, t game.CardTemplate,
 
 	// --- Attack and Health badges (bottom corners) ---
 	badgeR := rect.W * 0.07
-	r.drawAttackBadge(screen, t.Attack(),
+	r.drawAttackBadge(screen, t.Attack(), false,
 		rect.X+rect.W*0.12, rect.Bottom()-rect.H*0.08, badgeR, 255)
-	r.drawHealthBadge(screen, t.Health(),
+	r.drawHealthBadge(screen, t.Health(), false,
 		rect.Right()-rect.W*0.12, rect.Bottom()-rect.H*0.08, badgeR, 255)
 
 	// --- Tribe (bottom-center between badges) ---
@@ -198,8 +198,8 @@ func (r *CardRenderer) drawHandMinion(screen *ebiten.Image, c api.Card, rect ui.
 
 	// Attack and health badges.
 	badgeR := rect.W * 0.09
-	r.drawAttackBadge(screen, c.Attack, rect.X+rect.W*0.10, rect.Bottom()-rect.H*0.10, badgeR, 255)
-	r.drawHealthBadge(screen, c.Health, rect.Right()-rect.W*0.10, rect.Bottom()-rect.H*0.10, badgeR, 255)
+	r.drawAttackBadge(screen, c.Attack, c.AuraAttack, rect.X+rect.W*0.10, rect.Bottom()-rect.H*0.10, badgeR, 255)
+	r.drawHealthBadge(screen, c.Health, c.AuraHealth, rect.Right()-rect.W*0.10, rect.Bottom()-rect.H*0.10, badgeR, 255)
 }
 
 func (r *CardRenderer) drawHandSpell(screen *ebiten.Image, c api.Card, rect ui.Rect) {
@@ -254,7 +254,7 @@ func (r *CardRenderer) drawShopMinion(screen *ebiten.Image, c api.Card, rect ui.
 	r.drawKeywords(screen, c.Keywords, rect, 255)
 
 	// Keyword effects on top of text/art.
-	r.drawKeywordEffects(screen, rect, c.Keywords, 255, c.Attack, c.Health)
+	r.drawKeywordEffects(screen, rect, c, 255)
 
 	// Tier badge on top of everything.
 	if t != nil && t.Tier().IsValid() {
@@ -313,7 +313,7 @@ func (r *CardRenderer) DrawMinion(screen *ebiten.Image, c api.Card, rect ui.Rect
 	r.drawKeywords(screen, c.Keywords, rect, alpha)
 
 	// Keyword effects on top of text/art.
-	r.drawKeywordEffects(screen, rect, c.Keywords, alpha, c.Attack, c.Health)
+	r.drawKeywordEffects(screen, rect, c, alpha)
 }
 
 // drawKeywords renders keyword labels below the card center.
@@ -332,10 +332,10 @@ func (r *CardRenderer) drawKeywords(screen *ebiten.Image, kw game.Keywords, rect
 func (r *CardRenderer) drawKeywordEffects(
 	screen *ebiten.Image,
 	rect ui.Rect,
-	keywords game.Keywords,
+	c api.Card,
 	alpha uint8,
-	attack, health int,
 ) {
+	keywords := c.Keywords
 	sr := rect.Screen(r.Res)
 	s := r.Res.Scale()
 
@@ -361,8 +361,8 @@ func (r *CardRenderer) drawKeywordEffects(
 	bcx, bcy := rect.X+rect.W*0.5, rect.Y+rect.H*0.5
 	brx, bry := rect.W*0.5, rect.H*0.5
 	angle := math.Pi * 0.75
-	r.drawAttackBadge(screen, attack, bcx+brx*math.Cos(angle), bcy+bry*math.Sin(angle), badgeR, alpha)
-	r.drawHealthBadge(screen, health, bcx+brx*math.Cos(math.Pi-angle), bcy+bry*math.Sin(math.Pi-angle), badgeR, alpha)
+	r.drawAttackBadge(screen, c.Attack, c.AuraAttack, bcx+brx*math.Cos(angle), bcy+bry*math.Sin(angle), badgeR, alpha)
+	r.drawHealthBadge(screen, c.Health, c.AuraHealth, bcx+brx*math.Cos(math.Pi-angle), bcy+bry*math.Sin(math.Pi-angle), badgeR, alpha)
 
 	// Wind streaks in front of the minion (back pass is in drawEllipseBase).
 	if keywords.Has(game.KeywordWindfury) {
@@ -599,8 +599,16 @@ func withAlpha(c color.RGBA, alpha uint8) color.RGBA {
 	return c
 }
 
-// drawAttackBadge draws a gold circle with bold white number.
-func (r *CardRenderer) drawAttackBadge(screen *ebiten.Image, attack int, baseX, baseY, baseR float64, alpha uint8) {
+// statColor returns the badge number color: green when boosted by an aura.
+func statColor(boosted bool, alpha uint8) color.RGBA {
+	if boosted {
+		return color.RGBA{120, 255, 120, alpha}
+	}
+	return color.RGBA{255, 255, 255, alpha}
+}
+
+// drawAttackBadge draws a gold circle with bold number.
+func (r *CardRenderer) drawAttackBadge(screen *ebiten.Image, attack int, boosted bool, baseX, baseY, baseR float64, alpha uint8) {
 	s := r.Res.Scale()
 	ox := r.Res.OffsetX()
 	oy := r.Res.OffsetY()
@@ -614,14 +622,14 @@ func (r *CardRenderer) drawAttackBadge(screen *ebiten.Image, attack int, baseX,
 
 	op := &text.DrawOptions{}
 	op.GeoM.Translate(float64(sx), float64(sy))
-	op.ColorScale.ScaleWithColor(color.RGBA{255, 255, 255, alpha})
+	op.ColorScale.ScaleWithColor(statColor(boosted, alpha))
 	op.PrimaryAlign = text.AlignCenter
 	op.SecondaryAlign = text.AlignCenter
 	text.Draw(screen, strconv.Itoa(attack), r.BoldFont, op)
 }
 
-// drawHealthBadge draws a red circle with bold white number.
-func (r *CardRenderer) drawHealthBadge(screen *ebiten.Image, health int, baseX, baseY, baseR float64, alpha uint8) {
+// drawHealthBadge draws a red circle with bold number.
+func (r *CardRenderer) drawHealthBadge(screen *ebiten.Image, health int, boosted bool, baseX, baseY, baseR float64, alpha uint8) {
 	s := r.Res.Scale()
 	ox := r.Res.OffsetX()
 	oy := r.Res.OffsetY()
@@ -635,7 +643,7 @@ func (r *CardRenderer) drawHealthBadge(screen *ebiten.Image, health int, baseX,
 
 	op := &text.DrawOptions{}
 	op.GeoM.Translate(float64(sx), float64(sy))
-	op.ColorScale.ScaleWithColor(color.RGBA{255, 255, 255, alpha})
+	op.ColorScale.ScaleWithColor(statColor(boosted, alpha))
 	op.PrimaryAlign = text.AlignCenter
 	op.SecondaryAlign = text.AlignCenter
 	text.Draw(screen, strconv.Itoa(health), r.BoldFont, op)
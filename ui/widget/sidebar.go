@@ -47,6 +47,9 @@ func (s *Sidebar) Update(res ui.Resolution, rect ui.Rect, players []ui.PlayerEnt
 	}
 
 	for _, u := range updates {
+		if u.ShopTier == 0 {
+			continue
+		}
 		s.tierFades[u.Player] = tierFade{tier: u.ShopTier, timer: tierFadeDuration}
 	}
 
@@ -123,12 +126,19 @@ func (s *Sidebar) drawList(screen *ebiten.Image, res ui.Resolution, rect ui.Rect
 			)
 		}
 
-		// Line 1: Name + HP.
+		// Line 1: Name + HP + armor.
 		nameClr := color.RGBA{200, 200, 200, 255}
 		if e.ID == player {
 			nameClr = color.RGBA{100, 255, 100, 255}
 		}
-		ui.DrawText(screen, res, s.font, fmt.Sprintf("%d  %d HP", e.ID, e.HP), row.X+padX, row.Y+rowH*0.2, nameClr)
+		line1 := fmt.Sprintf("%d  %d HP", e.ID, e.HP)
+		if e.Armor > 0 {
+			line1 += fmt.Sprintf(" +%d", e.Armor)
+		}
+		ui.DrawText(screen, res, s.font, line1, row.X+padX, row.Y+rowH*0.2, nameClr)
+		if e.Ready {
+			ui.DrawText(screen, res, s.font, "Ready", row.X+row.W*0.70, row.Y+rowH*0.2, color.RGBA{100, 255, 100, 255})
+		}
 
 		// Line 2: Tier + tribe.
 		line2 := fmt.Sprintf("Tier %d", e.ShopTier)
@@ -222,10 +232,10 @@ func (s *Sidebar) drawTooltip(screen *ebiten.Image, res ui.Resolution, rect ui.R
 			label = fmt.Sprintf("Tie vs %d", cr.Opponent)
 			clr = color.RGBA{140, 140, 140, 255}
 		case e.ID:
-			label = fmt.Sprintf("Won vs %d (%d dmg)", cr.Opponent, cr.Damage)
+			label = fmt.Sprintf("Won vs %d (%s)", cr.Opponent, damageLabel(cr))
 			clr = color.RGBA{80, 220, 80, 255}
 		default:
-			label = fmt.Sprintf("Lost vs %d (%d dmg)", cr.Opponent, cr.Damage)
+			label = fmt.Sprintf("Lost vs %d (%s)", cr.Opponent, damageLabel(cr))
 			clr = color.RGBA{220, 80, 80, 255}
 		}
 		ui.DrawText(screen, res, s.font, label, tip.X+padX, y, clr)
@@ -236,3 +246,11 @@ func (s *Sidebar) drawTooltip(screen *ebiten.Image, res ui.Resolution, rect ui.R
 		ui.DrawText(screen, res, s.font, "No fights yet", tip.X+padX, tip.Y+tip.H*0.40, color.RGBA{100, 100, 100, 255})
 	}
 }
+
+// damageLabel formats combat damage, noting the raw damage when it was capped.
+func damageLabel(cr api.CombatResult) string {
+	if cr.RawDamage > cr.Damage {
+		return fmt.Sprintf("%d dmg, capped from %d", cr.Damage, cr.RawDamage)
+	}
+	return fmt.Sprintf("%d dmg", cr.Damage)
+}